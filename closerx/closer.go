@@ -0,0 +1,18 @@
+// Package closerx adapts teardown functions of different clients to io.Closer,
+// so shutdown routines can collect them in a slice and close them uniformly.
+package closerx
+
+import "io"
+
+// closerFunc adapts a plain function to io.Closer.
+type closerFunc func() error
+
+// Close calls f.
+func (f closerFunc) Close() error {
+	return f()
+}
+
+// AsCloser wraps closeFn as an io.Closer whose Close delegates to closeFn.
+func AsCloser(closeFn func() error) io.Closer {
+	return closerFunc(closeFn)
+}
@@ -0,0 +1,46 @@
+package closerx
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsCloser(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	closer := AsCloser(func() error {
+		calls++
+		return nil
+	})
+
+	assert.NoError(t, closer.Close())
+	assert.Equal(t, 1, calls)
+}
+
+func TestAsCloser_Error(t *testing.T) {
+	t.Parallel()
+
+	wantErr := errors.New("close failed")
+	closer := AsCloser(func() error { return wantErr })
+
+	assert.ErrorIs(t, closer.Close(), wantErr)
+}
+
+func TestAsCloser_ReverseOrder(t *testing.T) {
+	t.Parallel()
+
+	var order []string
+	closers := []io.Closer{
+		AsCloser(func() error { order = append(order, "db"); return nil }),
+		AsCloser(func() error { order = append(order, "redis"); return nil }),
+	}
+	for i := len(closers) - 1; i >= 0; i-- {
+		assert.NoError(t, closers[i].Close())
+	}
+
+	assert.Equal(t, []string{"redis", "db"}, order)
+}
@@ -1,14 +1,22 @@
 package consulx
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/consul/api"
+	"github.com/kwstars/go-bootstrap/internal/secret"
 )
 
+// defaultMaxResponseHeaderBytes caps response headers unless configured otherwise
+const defaultMaxResponseHeaderBytes = 1 << 20 // 1MB
+
 // ClientOption defines client configuration options
 type ClientOption func(*clientConfig)
 
@@ -27,14 +35,24 @@ type clientConfig struct {
 	transport  *http.Transport
 	httpClient *http.Client
 
+	// transportTweaks run against the final transport
+	transportTweaks []func(*http.Transport)
+
+	// rawConfig runs against the final api.Config
+	rawConfig []func(*api.Config)
+
 	// TLS configuration
-	tlsConfig *api.TLSConfig
+	tlsConfig     *api.TLSConfig
+	tlsMinVersion uint16
 
 	// Timeout configuration
 	waitTime time.Duration
 
 	// Other configuration
 	scheme string
+
+	// err records the first error raised while applying options
+	err error
 }
 
 // NewClient creates a Consul client
@@ -44,19 +62,83 @@ func NewClient(address string, opts ...ClientOption) (*api.Client, error) {
 		return nil, fmt.Errorf("address is required")
 	}
 
-	// Initialize default configuration
+	// Initialize default configuration and apply all options
+	cfg := newClientConfig(opts...)
+	if cfg.err != nil {
+		return nil, fmt.Errorf("apply option failed: %w", cfg.err)
+	}
+
+	// Build Consul API Config
+	config := buildConfig(address, cfg)
+
+	// Create client
+	client, err := api.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consul client: %w", err)
+	}
+
+	// Set custom Headers
+	if len(cfg.headers) > 0 {
+		client.SetHeaders(cfg.headers)
+	}
+
+	return client, nil
+}
+
+// DumpConfig renders the effective client configuration for diagnostics
+// Tokens, passwords and header values are masked as ***
+func DumpConfig(address string, opts ...ClientOption) string {
+	cfg := newClientConfig(opts...)
+	config := buildConfig(address, cfg)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "address=%s scheme=%s", config.Address, config.Scheme)
+	fmt.Fprintf(&b, " datacenter=%s namespace=%s partition=%s", config.Datacenter, config.Namespace, config.Partition)
+	fmt.Fprintf(&b, " token=%s token_file=%s", secret.Mask(config.Token), config.TokenFile)
+	if config.HttpAuth != nil {
+		fmt.Fprintf(&b, " basic_auth=%s:%s", config.HttpAuth.Username, secret.Mask(config.HttpAuth.Password))
+	}
+	fmt.Fprintf(&b, " tls_ca_file=%s tls_cert_file=%s tls_key_file=%s tls_insecure=%t",
+		config.TLSConfig.CAFile, config.TLSConfig.CertFile, config.TLSConfig.KeyFile, config.TLSConfig.InsecureSkipVerify)
+	fmt.Fprintf(&b, " wait_time=%s", config.WaitTime)
+
+	names := make([]string, 0, len(cfg.headers))
+	for name := range cfg.headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, " header[%s]=***", name)
+	}
+	if cfg.err != nil {
+		fmt.Fprintf(&b, " error=%q", cfg.err.Error())
+	}
+	return b.String()
+}
+
+// newClientConfig returns the default configuration with opts applied
+func newClientConfig(opts ...ClientOption) *clientConfig {
 	cfg := &clientConfig{
 		scheme:   "http",
 		waitTime: 0,
 		headers:  make(http.Header),
 	}
-
-	// Apply all options
 	for _, opt := range opts {
 		opt(cfg)
 	}
+	// A custom HTTP client brings its own transport, so tweaks would never run
+	if cfg.httpClient != nil && len(cfg.transportTweaks) > 0 {
+		cfg.setErr(fmt.Errorf("transport tweaks cannot be combined with a custom HTTP client"))
+	}
+	// Nor would the TLS floor, which must not be dropped silently
+	if cfg.httpClient != nil && cfg.tlsMinVersion != 0 {
+		cfg.setErr(fmt.Errorf("tls min version cannot be combined with a custom HTTP client"))
+	}
+	return cfg
+}
 
-	// Build Consul API Config
+// buildConfig maps the applied options onto a Consul API Config
+func buildConfig(address string, cfg *clientConfig) *api.Config {
 	config := api.DefaultConfig()
 	config.Address = address
 	config.Scheme = cfg.scheme
@@ -93,9 +175,10 @@ func NewClient(address string, opts ...ClientOption) (*api.Client, error) {
 		config.WaitTime = cfg.waitTime
 	}
 
-	// Set Transport
+	// Set Transport; the defaults, TLS floor and tweaks below go on a clone, as the caller's
+	// transport may be shared with other clients
 	if cfg.transport != nil {
-		config.Transport = cfg.transport
+		config.Transport = cfg.transport.Clone()
 	}
 
 	// Set HTTP client
@@ -103,18 +186,42 @@ func NewClient(address string, opts ...ClientOption) (*api.Client, error) {
 		config.HttpClient = cfg.httpClient
 	}
 
-	// Create client
-	client, err := api.NewClient(config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create consul client: %w", err)
+	// Pin the minimum TLS version on the resolved tls.Config
+	// A TLS setup error is left for api.NewClient to report, as it runs the same setup
+	if cfg.tlsMinVersion != 0 && config.Transport != nil {
+		if config.Transport.TLSClientConfig == nil {
+			if tlsClientConfig, err := api.SetupTLSConfig(&config.TLSConfig); err == nil {
+				config.Transport.TLSClientConfig = tlsClientConfig
+			}
+		}
+		if config.Transport.TLSClientConfig != nil {
+			config.Transport.TLSClientConfig.MinVersion = cfg.tlsMinVersion
+		}
 	}
 
-	// Set custom Headers
-	if len(cfg.headers) > 0 {
-		client.SetHeaders(cfg.headers)
+	// Cap response header size and apply transport tweaks
+	if config.Transport != nil {
+		if config.Transport.MaxResponseHeaderBytes == 0 {
+			config.Transport.MaxResponseHeaderBytes = defaultMaxResponseHeaderBytes
+		}
+		for _, tweak := range cfg.transportTweaks {
+			tweak(config.Transport)
+		}
 	}
 
-	return client, nil
+	// Apply raw config mutations last
+	for _, fn := range cfg.rawConfig {
+		fn(config)
+	}
+
+	return config
+}
+
+// setErr records err unless an earlier option already failed
+func (c *clientConfig) setErr(err error) {
+	if c.err == nil {
+		c.err = err
+	}
 }
 
 // ==================== Authentication related options ====================
@@ -143,6 +250,16 @@ func WithBasicAuth(username, password string) ClientOption {
 	}
 }
 
+// WithRawConfig registers a function that can mutate any field of the final api.Config
+// (e.g. PathPrefix); it runs after all typed options, just before api.NewClient
+func WithRawConfig(fn func(*api.Config)) ClientOption {
+	return func(c *clientConfig) {
+		if fn != nil {
+			c.rawConfig = append(c.rawConfig, fn)
+		}
+	}
+}
+
 // ==================== Location related options ====================
 
 // WithDatacenter sets the datacenter
@@ -202,9 +319,25 @@ func WithInsecureTLS() ClientOption {
 	}
 }
 
+// WithTLSMinVersion sets the minimum TLS version, one of the tls.VersionTLS* constants
+// It is applied to the tls.Config resolved from the TLS options; combining it with WithHTTPClient
+// makes NewClient fail, since the custom client's transport is not managed here
+func WithTLSMinVersion(version uint16) ClientOption {
+	return func(c *clientConfig) {
+		switch version {
+		case tls.VersionTLS10, tls.VersionTLS11, tls.VersionTLS12, tls.VersionTLS13:
+			c.tlsMinVersion = version
+		default:
+			c.setErr(fmt.Errorf("invalid tls min version: %#04x", version))
+		}
+	}
+}
+
 // ==================== HTTP related options ====================
 
 // WithHTTPClient sets custom HTTP client
+// The client is used as is: transport options, including the default response header cap, do not
+// apply to it, and combining it with WithTransportTweak or WithTLSMinVersion makes NewClient fail
 func WithHTTPClient(client *http.Client) ClientOption {
 	return func(c *clientConfig) {
 		c.httpClient = client
@@ -218,6 +351,17 @@ func WithTransport(transport *http.Transport) ClientOption {
 	}
 }
 
+// WithTransportTweak registers a function that can mutate any field of the final Transport
+// (e.g. MaxResponseHeaderBytes); tweaks run in order after all other options and defaults
+// Tweaks cannot be combined with WithHTTPClient, whose transport is not managed here
+func WithTransportTweak(tweak func(*http.Transport)) ClientOption {
+	return func(c *clientConfig) {
+		if tweak != nil {
+			c.transportTweaks = append(c.transportTweaks, tweak)
+		}
+	}
+}
+
 // WithHeaders sets custom HTTP headers
 func WithHeaders(headers http.Header) ClientOption {
 	return func(c *clientConfig) {
@@ -232,6 +376,26 @@ func WithHeader(key, value string) ClientOption {
 	}
 }
 
+// WithProxyURL routes requests through an HTTP or SOCKS5 proxy
+// proxyURL format like "http://proxy.example.com:3128" or "socks5://127.0.0.1:1080"
+func WithProxyURL(proxyURL string) ClientOption {
+	return func(c *clientConfig) {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			c.setErr(fmt.Errorf("invalid proxy url: %w", err))
+			return
+		}
+		if u.Scheme == "" || u.Host == "" {
+			c.setErr(fmt.Errorf("invalid proxy url %q: scheme and host are required", proxyURL))
+			return
+		}
+		if c.transport == nil {
+			c.transport = http.DefaultTransport.(*http.Transport).Clone()
+		}
+		c.transport.Proxy = http.ProxyURL(u)
+	}
+}
+
 // ==================== Timeout related options ====================
 
 // WithTimeout sets connection and request timeout
@@ -245,6 +409,20 @@ func WithTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
+// WithResponseHeaderTimeout sets how long to wait for response headers after writing a request
+func WithResponseHeaderTimeout(timeout time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		if timeout <= 0 {
+			c.setErr(fmt.Errorf("response header timeout must be positive"))
+			return
+		}
+		if c.transport == nil {
+			c.transport = http.DefaultTransport.(*http.Transport).Clone()
+		}
+		c.transport.ResponseHeaderTimeout = timeout
+	}
+}
+
 // WithWaitTime sets the maximum wait time for blocking queries
 func WithWaitTime(waitTime time.Duration) ClientOption {
 	return func(c *clientConfig) {
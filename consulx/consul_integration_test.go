@@ -1,6 +1,6 @@
 //go:build integration
 
-package consulx
+package consulx_test
 
 import (
 	"fmt"
@@ -13,7 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"lumina/pkg/consulx" // replace with your actual module path
+	"github.com/kwstars/go-bootstrap/consulx"
 )
 
 // These tests require a real Consul server
@@ -1,6 +1,7 @@
 package consulx
 
 import (
+	"crypto/tls"
 	"net/http"
 	"os"
 	"testing"
@@ -115,6 +116,31 @@ func TestWithInsecureTLS(t *testing.T) {
 	assert.True(t, cfg.tlsConfig.InsecureSkipVerify)
 }
 
+// TestWithTLSMinVersion test the minimum TLS version reaches the resolved tls.Config
+func TestWithTLSMinVersion(t *testing.T) {
+	cfg := &clientConfig{headers: make(http.Header)}
+	WithInsecureTLS()(cfg)
+	WithTLSMinVersion(tls.VersionTLS12)(cfg)
+	require.NoError(t, cfg.err)
+
+	config := buildConfig("127.0.0.1:8500", cfg)
+	require.NotNil(t, config.Transport.TLSClientConfig)
+	assert.Equal(t, uint16(tls.VersionTLS12), config.Transport.TLSClientConfig.MinVersion)
+	assert.True(t, config.Transport.TLSClientConfig.InsecureSkipVerify)
+
+	client, err := NewClient("127.0.0.1:8500", WithTLSMinVersion(tls.VersionTLS13))
+	require.NoError(t, err)
+	require.NotNil(t, client)
+}
+
+// TestWithTLSMinVersion_Invalid test unknown TLS versions are rejected
+func TestWithTLSMinVersion_Invalid(t *testing.T) {
+	client, err := NewClient("127.0.0.1:8500", WithTLSMinVersion(0x0305))
+	assert.Error(t, err)
+	assert.Nil(t, client)
+	assert.Contains(t, err.Error(), "invalid tls min version")
+}
+
 // TestWithHTTPClient test custom HTTP client
 func TestWithHTTPClient(t *testing.T) {
 	cfg := &clientConfig{headers: make(http.Header)}
@@ -155,6 +181,33 @@ func TestWithHeader(t *testing.T) {
 	assert.Equal(t, "secret", cfg.headers.Get("X-API-Key"))
 }
 
+// TestWithProxyURL test proxy configuration
+func TestWithProxyURL(t *testing.T) {
+	cfg := &clientConfig{headers: make(http.Header)}
+	opt := WithProxyURL("http://proxy.example.com:3128")
+	opt(cfg)
+	require.NoError(t, cfg.err)
+	require.NotNil(t, cfg.transport)
+	require.NotNil(t, cfg.transport.Proxy)
+
+	req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1:8500/v1/agent/self", nil)
+	require.NoError(t, err)
+	proxy, err := cfg.transport.Proxy(req)
+	require.NoError(t, err)
+	require.NotNil(t, proxy)
+	assert.Equal(t, "http://proxy.example.com:3128", proxy.String())
+}
+
+// TestWithProxyURL_Invalid test invalid proxy url is reported by NewClient
+func TestWithProxyURL_Invalid(t *testing.T) {
+	for _, proxyURL := range []string{"", "proxy.example.com", "http://[::1"} {
+		client, err := NewClient("127.0.0.1:8500", WithProxyURL(proxyURL))
+		assert.Error(t, err, proxyURL)
+		assert.Nil(t, client)
+		assert.Contains(t, err.Error(), "invalid proxy url")
+	}
+}
+
 // TestWithTimeout test timeout configuration
 func TestWithTimeout(t *testing.T) {
 	cfg := &clientConfig{headers: make(http.Header)}
@@ -165,6 +218,121 @@ func TestWithTimeout(t *testing.T) {
 	assert.Equal(t, 30*time.Second, cfg.transport.IdleConnTimeout)
 }
 
+// TestWithResponseHeaderTimeout test response header timeout
+func TestWithResponseHeaderTimeout(t *testing.T) {
+	cfg := &clientConfig{headers: make(http.Header)}
+	opt := WithResponseHeaderTimeout(15 * time.Second)
+	opt(cfg)
+	require.NoError(t, cfg.err)
+	require.NotNil(t, cfg.transport)
+	assert.Equal(t, 15*time.Second, cfg.transport.ResponseHeaderTimeout)
+
+	_, err := NewClient("127.0.0.1:8500", WithResponseHeaderTimeout(0))
+	assert.ErrorContains(t, err, "response header timeout must be positive")
+}
+
+// TestBuildConfig_DefaultHeaderCap test response headers are capped by default
+func TestBuildConfig_DefaultHeaderCap(t *testing.T) {
+	cfg := &clientConfig{scheme: "http", headers: make(http.Header)}
+	config := buildConfig("127.0.0.1:8500", cfg)
+	require.NotNil(t, config.Transport)
+	assert.Equal(t, int64(defaultMaxResponseHeaderBytes), config.Transport.MaxResponseHeaderBytes)
+}
+
+// TestWithTransportTweak test tweak receives and mutates the built transport
+func TestWithTransportTweak(t *testing.T) {
+	var received *http.Transport
+	cfg := &clientConfig{scheme: "http", headers: make(http.Header)}
+	for _, opt := range []ClientOption{
+		WithTimeout(10 * time.Second),
+		WithTransportTweak(func(tr *http.Transport) {
+			received = tr
+			tr.MaxResponseHeaderBytes = 64 << 10
+		}),
+		WithTransportTweak(nil),
+	} {
+		opt(cfg)
+	}
+
+	config := buildConfig("127.0.0.1:8500", cfg)
+	require.NotNil(t, received)
+	assert.Same(t, config.Transport, received)
+	assert.Equal(t, int64(64<<10), config.Transport.MaxResponseHeaderBytes)
+	assert.Equal(t, 10*time.Second, config.Transport.ResponseHeaderTimeout)
+}
+
+// TestWithTransportTweak_HTTPClientConflict test tweaks are rejected alongside a custom HTTP client
+func TestWithTransportTweak_HTTPClientConflict(t *testing.T) {
+	tweak := WithTransportTweak(func(tr *http.Transport) { tr.MaxResponseHeaderBytes = 64 << 10 })
+
+	// Either order is rejected, since the custom client's transport would never see the tweak
+	for _, opts := range [][]ClientOption{
+		{WithHTTPClient(&http.Client{}), tweak},
+		{tweak, WithHTTPClient(&http.Client{})},
+	} {
+		_, err := NewClient("127.0.0.1:8500", opts...)
+		assert.ErrorContains(t, err, "transport tweaks cannot be combined with a custom HTTP client")
+	}
+
+	// Without tweaks the custom client is used as is
+	httpClient := &http.Client{Timeout: 3 * time.Second}
+	config := buildConfig("127.0.0.1:8500", newClientConfig(WithHTTPClient(httpClient)))
+	assert.Same(t, httpClient, config.HttpClient)
+}
+
+// TestWithTLSMinVersion_HTTPClientConflict test the TLS floor is rejected alongside a custom HTTP client
+func TestWithTLSMinVersion_HTTPClientConflict(t *testing.T) {
+	for _, opts := range [][]ClientOption{
+		{WithHTTPClient(&http.Client{}), WithTLSMinVersion(tls.VersionTLS13)},
+		{WithTLSMinVersion(tls.VersionTLS13), WithHTTPClient(&http.Client{})},
+	} {
+		_, err := NewClient("127.0.0.1:8500", opts...)
+		assert.ErrorContains(t, err, "tls min version cannot be combined with a custom HTTP client")
+	}
+}
+
+// TestBuildConfig_LeavesCallerTransportUnchanged test defaults and tweaks go on a copy of WithTransport's transport
+func TestBuildConfig_LeavesCallerTransportUnchanged(t *testing.T) {
+	shared := &http.Transport{}
+	cfg := newClientConfig(
+		WithTransport(shared),
+		WithTLSMinVersion(tls.VersionTLS13),
+		WithTransportTweak(func(tr *http.Transport) { tr.MaxIdleConns = 7 }),
+	)
+	require.NoError(t, cfg.err)
+
+	config := buildConfig("127.0.0.1:8500", cfg)
+	require.NotNil(t, config.Transport)
+	assert.NotSame(t, shared, config.Transport)
+	assert.Equal(t, int64(defaultMaxResponseHeaderBytes), config.Transport.MaxResponseHeaderBytes)
+	assert.Equal(t, uint16(tls.VersionTLS13), config.Transport.TLSClientConfig.MinVersion)
+	assert.Equal(t, 7, config.Transport.MaxIdleConns)
+
+	assert.Zero(t, shared.MaxResponseHeaderBytes)
+	assert.Zero(t, shared.MaxIdleConns)
+	// Clone may initialise the original's HTTP/2 TLS defaults, but never with our floor
+	if shared.TLSClientConfig != nil {
+		assert.NotSame(t, shared.TLSClientConfig, config.Transport.TLSClientConfig)
+		assert.Zero(t, shared.TLSClientConfig.MinVersion)
+	}
+}
+
+// TestWithRawConfig test raw config mutations reach the built config
+func TestWithRawConfig(t *testing.T) {
+	cfg := &clientConfig{headers: make(http.Header)}
+	WithDatacenter("dc1")(cfg)
+	WithRawConfig(func(c *api.Config) {
+		c.PathPrefix = "/consul"
+		c.Datacenter = "dc2"
+	})(cfg)
+	WithRawConfig(nil)(cfg)
+
+	config := buildConfig("127.0.0.1:8500", cfg)
+	assert.Equal(t, "/consul", config.PathPrefix)
+	assert.Equal(t, "dc2", config.Datacenter)
+	assert.Len(t, cfg.rawConfig, 1)
+}
+
 // TestWithWaitTime test wait time configuration
 func TestWithWaitTime(t *testing.T) {
 	cfg := &clientConfig{headers: make(http.Header)}
@@ -302,3 +470,38 @@ func BenchmarkNewClient_WithOptions(b *testing.B) {
 		)
 	}
 }
+
+// TestDumpConfig test secrets are masked in the dumped configuration
+func TestDumpConfig(t *testing.T) {
+	out := DumpConfig("consul.internal:8500",
+		WithToken("acl-token-secret"),
+		WithTokenFile("/etc/consul/token"),
+		WithBasicAuth("admin", "basic-pass-secret"),
+		WithHeader("X-Api-Key", "header-secret"),
+		WithDatacenter("dc2"),
+		WithNamespace("team-a"),
+		WithTLS("/tls/ca.pem", "/tls/cert.pem", "/tls/key.pem"),
+		WithWaitTime(30*time.Second),
+	)
+
+	for _, secret := range []string{"acl-token-secret", "basic-pass-secret", "header-secret"} {
+		assert.NotContains(t, out, secret)
+	}
+	assert.Contains(t, out, "address=consul.internal:8500")
+	assert.Contains(t, out, "scheme=https")
+	assert.Contains(t, out, "token=***")
+	assert.Contains(t, out, "token_file=/etc/consul/token")
+	assert.Contains(t, out, "basic_auth=admin:***")
+	assert.Contains(t, out, "header[X-Api-Key]=***")
+	assert.Contains(t, out, "datacenter=dc2")
+	assert.Contains(t, out, "namespace=team-a")
+	assert.Contains(t, out, "tls_ca_file=/tls/ca.pem")
+	assert.Contains(t, out, "wait_time=30s")
+}
+
+// TestDumpConfig_NoSecrets test unset secrets are not reported as masked
+func TestDumpConfig_NoSecrets(t *testing.T) {
+	out := DumpConfig("127.0.0.1:8500")
+	assert.NotContains(t, out, "***")
+	assert.Contains(t, out, "scheme=http")
+}
@@ -0,0 +1,132 @@
+package consulx
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+)
+
+// watchRetryInterval is the pause between failed blocking queries in WatchService
+const watchRetryInterval = time.Second
+
+// ResolveOption defines service resolution options
+type ResolveOption func(*resolveConfig)
+
+// resolveConfig internal resolution configuration
+type resolveConfig struct {
+	includeTags []string
+	excludeTags []string
+}
+
+// WithTags keeps only instances carrying every include tag and none of the exclude tags
+// Filtering is applied to the health query result, so it works with any Consul version
+func WithTags(include, exclude []string) ResolveOption {
+	return func(c *resolveConfig) {
+		c.includeTags = include
+		c.excludeTags = exclude
+	}
+}
+
+// ResolveService returns the passing instances of service
+func ResolveService(ctx context.Context, client *api.Client, service string, opts ...ResolveOption) ([]*api.ServiceEntry, error) {
+	cfg := newResolveConfig(opts...)
+
+	entries, _, err := client.Health().Service(service, "", true, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("resolve service %s: %w", service, err)
+	}
+	return cfg.filter(entries), nil
+}
+
+// WatchService resolves service and then sends the passing instances again whenever they change,
+// using Consul blocking queries
+// Failed queries are retried; the channel is closed when ctx is done
+func WatchService(ctx context.Context, client *api.Client, service string, opts ...ResolveOption) (<-chan []*api.ServiceEntry, error) {
+	cfg := newResolveConfig(opts...)
+
+	entries, meta, err := client.Health().Service(service, "", true, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("watch service %s: %w", service, err)
+	}
+
+	ch := make(chan []*api.ServiceEntry, 1)
+	ch <- cfg.filter(entries)
+
+	go func() {
+		defer close(ch)
+
+		index := meta.LastIndex
+		for {
+			q := (&api.QueryOptions{WaitIndex: index}).WithContext(ctx)
+			entries, meta, err := client.Health().Service(service, "", true, q)
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(watchRetryInterval):
+					continue
+				}
+			}
+			// Blocking queries may return on timeout without changes
+			if meta.LastIndex == index {
+				continue
+			}
+			// Reset the index if it goes backwards, e.g. after a snapshot restore
+			if meta.LastIndex < index {
+				index = 0
+			} else {
+				index = meta.LastIndex
+			}
+
+			select {
+			case ch <- cfg.filter(entries):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// newResolveConfig returns the resolution configuration with opts applied
+func newResolveConfig(opts ...ResolveOption) *resolveConfig {
+	cfg := &resolveConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// filter drops entries that do not match the tag filters
+func (c *resolveConfig) filter(entries []*api.ServiceEntry) []*api.ServiceEntry {
+	if len(c.includeTags) == 0 && len(c.excludeTags) == 0 {
+		return entries
+	}
+
+	filtered := make([]*api.ServiceEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Service != nil && c.matches(entry.Service.Tags) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// matches reports whether tags contain every include tag and no exclude tag
+func (c *resolveConfig) matches(tags []string) bool {
+	for _, tag := range c.includeTags {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	for _, tag := range c.excludeTags {
+		if slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
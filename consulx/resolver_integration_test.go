@@ -0,0 +1,89 @@
+//go:build integration
+
+package consulx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registerTagged registers a passing instance of web with the given tags
+func registerTagged(t *testing.T, client *api.Client, id string, tags ...string) {
+	t.Helper()
+	err := client.Agent().ServiceRegister(&api.AgentServiceRegistration{
+		ID:    id,
+		Name:  "web",
+		Tags:  tags,
+		Port:  8080,
+		Check: &api.AgentServiceCheck{TTL: "1m", Status: api.HealthPassing},
+	})
+	require.NoError(t, err)
+}
+
+// TestIntegration_ResolveServiceWithTags test tag filtering against a real agent
+func TestIntegration_ResolveServiceWithTags(t *testing.T) {
+	server, err := testutil.NewTestServerConfigT(t, nil)
+	require.NoError(t, err)
+	defer server.Stop()
+
+	client, err := NewClient(server.HTTPAddr)
+	require.NoError(t, err)
+
+	registerTagged(t, client, "web-1", "v2")
+	registerTagged(t, client, "web-2", "v2", "canary")
+	registerTagged(t, client, "web-3", "v1")
+
+	ctx := context.Background()
+	require.Eventually(t, func() bool {
+		entries, err := ResolveService(ctx, client, "web")
+		return err == nil && len(entries) == 3
+	}, 10*time.Second, 100*time.Millisecond)
+
+	entries, err := ResolveService(ctx, client, "web", WithTags([]string{"v2"}, []string{"canary"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web-1"}, entryIDs(entries))
+
+	entries, err = ResolveService(ctx, client, "web", WithTags(nil, []string{"canary"}))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"web-1", "web-3"}, entryIDs(entries))
+}
+
+// TestIntegration_WatchServiceWithTags test tag filtering on watch updates against a real agent
+func TestIntegration_WatchServiceWithTags(t *testing.T) {
+	server, err := testutil.NewTestServerConfigT(t, nil)
+	require.NoError(t, err)
+	defer server.Stop()
+
+	client, err := NewClient(server.HTTPAddr)
+	require.NoError(t, err)
+
+	registerTagged(t, client, "web-1", "v2")
+	registerTagged(t, client, "web-2", "v2", "canary")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := WatchService(ctx, client, "web", WithTags([]string{"v2"}, []string{"canary"}))
+	require.NoError(t, err)
+
+	registerTagged(t, client, "web-3", "v2")
+
+	deadline := time.After(10 * time.Second)
+	for {
+		select {
+		case entries := <-ch:
+			if assert.NotContains(t, entryIDs(entries), "web-2") && len(entries) == 2 {
+				assert.ElementsMatch(t, []string{"web-1", "web-3"}, entryIDs(entries))
+				return
+			}
+		case <-deadline:
+			t.Fatal("timed out waiting for filtered watch update")
+		}
+	}
+}
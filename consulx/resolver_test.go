@@ -0,0 +1,165 @@
+package consulx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHealth serves /v1/health/service/ with blocking query support
+type fakeHealth struct {
+	mu      sync.Mutex
+	index   uint64
+	entries []*api.ServiceEntry
+	changed chan struct{}
+}
+
+func newFakeHealth(t *testing.T, entries ...*api.ServiceEntry) (*fakeHealth, *api.Client) {
+	t.Helper()
+	f := &fakeHealth{index: 1, entries: entries, changed: make(chan struct{})}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient(srv.Listener.Addr().String())
+	require.NoError(t, err)
+	return f, client
+}
+
+func (f *fakeHealth) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	waitIndex, _ := strconv.ParseUint(r.URL.Query().Get("index"), 10, 64)
+
+	f.mu.Lock()
+	if waitIndex >= f.index {
+		changed := f.changed
+		f.mu.Unlock()
+		select {
+		case <-changed:
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+			return
+		}
+		f.mu.Lock()
+	}
+	index, entries := f.index, f.entries
+	f.mu.Unlock()
+
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(index, 10))
+	_ = json.NewEncoder(w).Encode(entries)
+}
+
+// set replaces the registered instances and wakes blocked queries
+func (f *fakeHealth) set(entries ...*api.ServiceEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.index++
+	f.entries = entries
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+func taggedEntry(id string, tags ...string) *api.ServiceEntry {
+	return &api.ServiceEntry{
+		Node:    &api.Node{Node: "node-1"},
+		Service: &api.AgentService{ID: id, Service: "web", Tags: tags},
+	}
+}
+
+func entryIDs(entries []*api.ServiceEntry) []string {
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.Service.ID)
+	}
+	return ids
+}
+
+// TestResolveService_WithTags test include/exclude tag filtering on a one-shot resolve
+func TestResolveService_WithTags(t *testing.T) {
+	_, client := newFakeHealth(t,
+		taggedEntry("web-1", "v2"),
+		taggedEntry("web-2", "v2", "canary"),
+		taggedEntry("web-3", "v1"),
+	)
+	ctx := context.Background()
+
+	entries, err := ResolveService(ctx, client, "web")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web-1", "web-2", "web-3"}, entryIDs(entries))
+
+	entries, err = ResolveService(ctx, client, "web", WithTags([]string{"v2"}, nil))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web-1", "web-2"}, entryIDs(entries))
+
+	entries, err = ResolveService(ctx, client, "web", WithTags(nil, []string{"canary"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web-1", "web-3"}, entryIDs(entries))
+
+	entries, err = ResolveService(ctx, client, "web", WithTags([]string{"v2"}, []string{"canary"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web-1"}, entryIDs(entries))
+}
+
+// TestWatchService_WithTags test filtering applies to every update on the watch channel
+func TestWatchService_WithTags(t *testing.T) {
+	fake, client := newFakeHealth(t,
+		taggedEntry("web-1", "v2"),
+		taggedEntry("web-2", "v2", "canary"),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := WatchService(ctx, client, "web", WithTags([]string{"v2"}, []string{"canary"}))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"web-1"}, entryIDs(receiveEntries(t, ch)))
+
+	fake.set(
+		taggedEntry("web-1", "v2"),
+		taggedEntry("web-2", "v2", "canary"),
+		taggedEntry("web-3", "v2"),
+		taggedEntry("web-4", "v1"),
+	)
+	assert.Equal(t, []string{"web-1", "web-3"}, entryIDs(receiveEntries(t, ch)))
+
+	cancel()
+	select {
+	case _, ok := <-ch:
+		assert.False(t, ok)
+	case <-time.After(2 * time.Second):
+		t.Fatal("watch channel not closed after cancel")
+	}
+}
+
+// TestWatchService_InitialError test the first query error is returned
+func TestWatchService_InitialError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(srv.Listener.Addr().String())
+	require.NoError(t, err)
+
+	_, err = WatchService(context.Background(), client, "web")
+	assert.Error(t, err)
+}
+
+func receiveEntries(t *testing.T, ch <-chan []*api.ServiceEntry) []*api.ServiceEntry {
+	t.Helper()
+	select {
+	case entries, ok := <-ch:
+		require.True(t, ok, "watch channel closed")
+		return entries
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watch update")
+		return nil
+	}
+}
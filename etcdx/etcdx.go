@@ -9,6 +9,8 @@ import (
 	"go.etcd.io/etcd/client/pkg/v3/transport"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
 )
 
 // Config simplified configuration (contains only the most common fields)
@@ -18,6 +20,12 @@ type Config struct {
 	Username  string      // Optional: username
 	Password  string      // Optional: password
 	Logger    *zap.Logger // Optional: logger
+
+	DialOptions []grpc.DialOption // Optional: extra gRPC dial options
+
+	TLSMinVersion uint16 // Optional: minimum TLS version (tls.VersionTLS*), applied when TLS is set
+
+	RawConfig []func(*clientv3.Config) // Optional: mutations applied just before clientv3.New
 }
 
 // Option function type for options
@@ -40,7 +48,31 @@ func New(endpoints []string, options ...Option) (*clientv3.Client, error) {
 		option(config)
 	}
 
+	// validate TLS min version
+	if config.TLSMinVersion != 0 && !isKnownTLSVersion(config.TLSMinVersion) {
+		return nil, fmt.Errorf("invalid TLS min version: %#04x", config.TLSMinVersion)
+	}
+
 	// build etcd config
+	etcdConfig := buildClientConfig(config)
+
+	// create etcd client
+	cli, err := clientv3.New(*etcdConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create etcd client failed: %w", err)
+	}
+
+	// check connection
+	if err := checkConnection(context.TODO(), cli); err != nil {
+		_ = cli.Close()
+		return nil, fmt.Errorf("etcd connection check failed: %w", err)
+	}
+
+	return cli, nil
+}
+
+// buildClientConfig maps the simplified config onto clientv3.Config
+func buildClientConfig(config *Config) *clientv3.Config {
 	etcdConfig := &clientv3.Config{
 		Endpoints:            config.Endpoints,
 		DialTimeout:          5 * time.Second,  // recommended for production
@@ -56,6 +88,11 @@ func New(endpoints []string, options ...Option) (*clientv3.Client, error) {
 	// set TLS if provided
 	if config.TLS != nil {
 		etcdConfig.TLS = config.TLS
+		if config.TLSMinVersion != 0 {
+			// clone so a tls.Config shared by the caller is not mutated
+			etcdConfig.TLS = config.TLS.Clone()
+			etcdConfig.TLS.MinVersion = config.TLSMinVersion
+		}
 	}
 
 	// set auth if provided
@@ -69,19 +106,26 @@ func New(endpoints []string, options ...Option) (*clientv3.Client, error) {
 		etcdConfig.Logger = config.Logger
 	}
 
-	// create etcd client
-	cli, err := clientv3.New(*etcdConfig)
-	if err != nil {
-		return nil, fmt.Errorf("create etcd client failed: %w", err)
+	// set extra dial options if provided
+	if len(config.DialOptions) > 0 {
+		etcdConfig.DialOptions = config.DialOptions
 	}
 
-	// check connection
-	if err := checkConnection(context.TODO(), cli); err != nil {
-		_ = cli.Close()
-		return nil, fmt.Errorf("etcd connection check failed: %w", err)
+	// apply raw config mutations last
+	for _, fn := range config.RawConfig {
+		fn(etcdConfig)
 	}
 
-	return cli, nil
+	return etcdConfig
+}
+
+// isKnownTLSVersion reports whether version is one of the tls.VersionTLS* constants
+func isKnownTLSVersion(version uint16) bool {
+	switch version {
+	case tls.VersionTLS10, tls.VersionTLS11, tls.VersionTLS12, tls.VersionTLS13:
+		return true
+	}
+	return false
 }
 
 // checkConnection verifies the connection
@@ -101,6 +145,36 @@ func checkConnection(ctx context.Context, cli *clientv3.Client) error {
 	return nil
 }
 
+// WaitReady polls the cluster member list every interval until it succeeds or ctx is done,
+// for gating startup on etcd availability
+// An already-done ctx returns its error without contacting the cluster
+func WaitReady(ctx context.Context, cli *clientv3.Client, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		attemptCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		_, err := cli.MemberList(attemptCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("etcd not ready: %w (last error: %v)", ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
+
 // --- Common option functions ---
 
 // WithTLS sets TLS config (certificate files)
@@ -129,6 +203,14 @@ func WithTLSConfig(tlsConfig *tls.Config) Option {
 	}
 }
 
+// WithTLSMinVersion sets the minimum TLS version (tls.VersionTLS*), validated in New
+// Applied to the TLS config built by WithTLS or passed to WithTLSConfig
+func WithTLSMinVersion(version uint16) Option {
+	return func(c *Config) {
+		c.TLSMinVersion = version
+	}
+}
+
 // WithAuth sets authentication info
 func WithAuth(username, password string) Option {
 	return func(c *Config) {
@@ -144,6 +226,48 @@ func WithLogger(logger *zap.Logger) Option {
 	}
 }
 
+// WithRetryLogging logs every failed unary attempt with its endpoint and error
+// The interceptor is chained inside etcd's retry interceptor, so each retry is logged separately
+func WithRetryLogging(zl *zap.Logger) Option {
+	return func(c *Config) {
+		if zl == nil {
+			return
+		}
+		c.DialOptions = append(c.DialOptions, grpc.WithChainUnaryInterceptor(retryLoggingInterceptor(zl)))
+	}
+}
+
+// retryLoggingInterceptor logs failed attempts that were not caused by the caller's context
+func retryLoggingInterceptor(zl *zap.Logger) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var p peer.Peer
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Peer(&p))...)
+		if err != nil && ctx.Err() == nil {
+			endpoint := cc.Target()
+			if p.Addr != nil {
+				endpoint = p.Addr.String()
+			}
+			zl.Warn("etcd request attempt failed",
+				zap.String("method", method),
+				zap.String("endpoint", endpoint),
+				zap.Error(err),
+			)
+		}
+		return err
+	}
+}
+
+// WithRawConfig mutates clientv3.Config directly, for fields without a dedicated option
+// (e.g. RejectOldCluster, MaxUnaryRetries); it runs after all other settings, just before clientv3.New
+func WithRawConfig(fn func(*clientv3.Config)) Option {
+	return func(c *Config) {
+		if fn == nil {
+			return
+		}
+		c.RawConfig = append(c.RawConfig, fn)
+	}
+}
+
 // WithTimeout sets timeouts (not commonly used)
 func WithTimeout(dialTimeout, keepAliveTime, keepAliveTimeout time.Duration) Option {
 	// Note: this option needs special handling because it directly affects clientv3.Config
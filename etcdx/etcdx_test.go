@@ -0,0 +1,192 @@
+package etcdx
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeEtcd serves the minimal KV and Cluster APIs needed by the client
+type fakeEtcd struct {
+	pb.UnimplementedKVServer
+	pb.UnimplementedClusterServer
+
+	rangeFailures atomic.Int32 // number of Range calls that fail before succeeding
+	rangeCalls    atomic.Int32
+
+	unhealthy   atomic.Bool // MemberList fails while set
+	memberCalls atomic.Int32
+}
+
+func (f *fakeEtcd) Range(context.Context, *pb.RangeRequest) (*pb.RangeResponse, error) {
+	if f.rangeCalls.Add(1) <= f.rangeFailures.Load() {
+		return nil, status.Error(codes.Unavailable, "fake etcd unavailable")
+	}
+	return &pb.RangeResponse{Header: &pb.ResponseHeader{}}, nil
+}
+
+func (f *fakeEtcd) MemberList(context.Context, *pb.MemberListRequest) (*pb.MemberListResponse, error) {
+	f.memberCalls.Add(1)
+	if f.unhealthy.Load() {
+		return nil, status.Error(codes.Unavailable, "fake etcd not ready")
+	}
+	return &pb.MemberListResponse{Header: &pb.ResponseHeader{}}, nil
+}
+
+// startFakeEtcd serves fake over an in-memory listener and returns an Option dialing it
+func startFakeEtcd(t *testing.T, fake *fakeEtcd) Option {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	pb.RegisterKVServer(srv, fake)
+	pb.RegisterClusterServer(srv, fake)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	return func(c *Config) {
+		c.DialOptions = append(c.DialOptions, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}))
+	}
+}
+
+func TestNew_EmptyEndpoints(t *testing.T) {
+	cli, err := New(nil)
+	assert.Error(t, err)
+	assert.Nil(t, cli)
+}
+
+func TestWithRetryLogging(t *testing.T) {
+	fake := &fakeEtcd{}
+	fake.rangeFailures.Store(1)
+	core, logs := observer.New(zap.WarnLevel)
+
+	cli, err := New([]string{"bufnet:2379"},
+		startFakeEtcd(t, fake),
+		WithLogger(zap.NewNop()),
+		WithRetryLogging(zap.New(core)),
+	)
+	require.NoError(t, err)
+	defer cli.Close()
+
+	_, err = cli.Get(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), fake.rangeCalls.Load())
+
+	entries := logs.FilterMessage("etcd request attempt failed").All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "/etcdserverpb.KV/Range", fields["method"])
+	assert.NotEmpty(t, fields["endpoint"])
+	assert.Contains(t, fields["error"], "fake etcd unavailable")
+}
+
+func TestWithRetryLogging_NilLogger(t *testing.T) {
+	cfg := &Config{}
+	WithRetryLogging(nil)(cfg)
+	assert.Empty(t, cfg.DialOptions)
+}
+
+func TestWithRawConfig(t *testing.T) {
+	cfg := &Config{Endpoints: []string{"127.0.0.1:2379"}}
+	WithRawConfig(func(c *clientv3.Config) {
+		c.RejectOldCluster = true
+		c.MaxUnaryRetries = 7
+	})(cfg)
+
+	etcdConfig := buildClientConfig(cfg)
+	assert.True(t, etcdConfig.RejectOldCluster)
+	assert.Equal(t, uint(7), etcdConfig.MaxUnaryRetries)
+	assert.Equal(t, []string{"127.0.0.1:2379"}, etcdConfig.Endpoints)
+}
+
+func TestWithRawConfig_NilFunc(t *testing.T) {
+	cfg := &Config{}
+	WithRawConfig(nil)(cfg)
+	assert.Empty(t, cfg.RawConfig)
+}
+
+func TestWaitReady(t *testing.T) {
+	fake := &fakeEtcd{}
+	cli, err := New([]string{"bufnet:2379"}, startFakeEtcd(t, fake), WithLogger(zap.NewNop()))
+	require.NoError(t, err)
+	defer cli.Close()
+
+	fake.unhealthy.Store(true)
+	calls := fake.memberCalls.Load()
+	time.AfterFunc(200*time.Millisecond, func() { fake.unhealthy.Store(false) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, WaitReady(ctx, cli, 20*time.Millisecond))
+	assert.Greater(t, fake.memberCalls.Load()-calls, int32(1))
+}
+
+func TestWaitReady_ContextCanceled(t *testing.T) {
+	fake := &fakeEtcd{}
+	cli, err := New([]string{"bufnet:2379"}, startFakeEtcd(t, fake), WithLogger(zap.NewNop()))
+	require.NoError(t, err)
+	defer cli.Close()
+
+	fake.unhealthy.Store(true)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	err = WaitReady(ctx, cli, 20*time.Millisecond)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestWaitReady_AlreadyCanceled(t *testing.T) {
+	fake := &fakeEtcd{}
+	cli, err := New([]string{"bufnet:2379"}, startFakeEtcd(t, fake), WithLogger(zap.NewNop()))
+	require.NoError(t, err)
+	defer cli.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := fake.memberCalls.Load()
+	assert.ErrorIs(t, WaitReady(ctx, cli, 20*time.Millisecond), context.Canceled)
+	assert.Equal(t, calls, fake.memberCalls.Load())
+}
+
+func TestWaitReady_InvalidInterval(t *testing.T) {
+	assert.Error(t, WaitReady(context.Background(), nil, 0))
+}
+
+func TestWithTLSMinVersion(t *testing.T) {
+	shared := &tls.Config{InsecureSkipVerify: true}
+	cfg := &Config{Endpoints: []string{"127.0.0.1:2379"}}
+	WithTLSConfig(shared)(cfg)
+	WithTLSMinVersion(tls.VersionTLS12)(cfg)
+
+	etcdConfig := buildClientConfig(cfg)
+	require.NotNil(t, etcdConfig.TLS)
+	assert.Equal(t, uint16(tls.VersionTLS12), etcdConfig.TLS.MinVersion)
+	assert.True(t, etcdConfig.TLS.InsecureSkipVerify)
+	assert.Zero(t, shared.MinVersion)
+}
+
+func TestWithTLSMinVersion_Invalid(t *testing.T) {
+	cli, err := New([]string{"127.0.0.1:2379"}, WithInsecure(), WithTLSMinVersion(0x0305))
+	assert.Error(t, err)
+	assert.Nil(t, cli)
+	assert.Contains(t, err.Error(), "invalid TLS min version")
+}
@@ -0,0 +1,168 @@
+package goredisx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Loader produces the value for a cache miss.
+type Loader func(ctx context.Context) ([]byte, error)
+
+// GetOrSet returns the value cached at key, calling loader and caching its result for ttl on a miss.
+// Concurrent misses may each call loader; the last write wins.
+func GetOrSet(ctx context.Context, client redis.UniversalClient, key string, ttl time.Duration, loader Loader) ([]byte, error) {
+	return GetOrSetWithReadPreference(ctx, client, nil, key, ttl, loader)
+}
+
+// GetOrSetWithReadPreference is GetOrSet with split reads: the cached value is read from replica
+// (e.g. a failover client created with ReplicaOnly, or a cluster client with ReadOnly) and a
+// loaded value is always written through primary. If the replica read fails for any reason other
+// than a miss, the read is retried on primary. The loaded value is returned directly rather than
+// re-read, so replication lag cannot hide a fresh write.
+// For standalone deployments pass a nil replica: reads and writes then both use primary and the
+// preference is a no-op.
+func GetOrSetWithReadPreference(ctx context.Context, primary, replica redis.UniversalClient, key string, ttl time.Duration, loader Loader) ([]byte, error) {
+	if loader == nil {
+		return nil, errors.New("loader cannot be nil")
+	}
+
+	val, err := getPreferred(ctx, primary, replica, key)
+	if err == nil {
+		return val, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("get %s: %w", key, err)
+	}
+
+	val, err = loader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load %s: %w", key, err)
+	}
+	if err := primary.Set(ctx, key, val, ttl).Err(); err != nil {
+		return nil, fmt.Errorf("set %s: %w", key, err)
+	}
+	return val, nil
+}
+
+// getPreferred reads key from replica when given, falling back to primary on replica errors.
+func getPreferred(ctx context.Context, primary, replica redis.UniversalClient, key string) ([]byte, error) {
+	if replica != nil {
+		val, err := replica.Get(ctx, key).Bytes()
+		if err == nil || errors.Is(err, redis.Nil) {
+			return val, err
+		}
+	}
+	return primary.Get(ctx, key).Bytes()
+}
+
+// CacheOption configures the struct-level cache helpers GetOrSetJSON, SetJSON and GetJSON.
+type CacheOption func(*cacheConfig) error
+
+type cacheConfig struct {
+	marshal   func(any) ([]byte, error)
+	unmarshal func([]byte, any) error
+}
+
+func newCacheConfig(opts []CacheOption) (*cacheConfig, error) {
+	cfg := &cacheConfig{marshal: json.Marshal, unmarshal: json.Unmarshal}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, fmt.Errorf("apply option failed: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// WithCacheSerializer replaces encoding/json as the value encoding of GetOrSetJSON, SetJSON and
+// GetJSON, e.g. with msgpack. Both sides of a key must use the same serializer.
+func WithCacheSerializer(marshal func(any) ([]byte, error), unmarshal func([]byte, any) error) CacheOption {
+	return func(c *cacheConfig) error {
+		if marshal == nil || unmarshal == nil {
+			return errors.New("serializer funcs cannot be nil")
+		}
+		c.marshal = marshal
+		c.unmarshal = unmarshal
+		return nil
+	}
+}
+
+// GetOrSetJSON is GetOrSet for struct values: it decodes the value cached at key, or calls loader
+// on a miss and caches its encoded result for ttl. Values are encoded as JSON unless
+// WithCacheSerializer is given. Concurrent misses may each call loader; the last write wins.
+func GetOrSetJSON[T any](ctx context.Context, client redis.UniversalClient, key string, ttl time.Duration, loader func(ctx context.Context) (T, error), opts ...CacheOption) (T, error) {
+	var zero T
+	if loader == nil {
+		return zero, errors.New("loader cannot be nil")
+	}
+	cfg, err := newCacheConfig(opts)
+	if err != nil {
+		return zero, err
+	}
+
+	val, err := client.Get(ctx, key).Bytes()
+	if err == nil {
+		var v T
+		if err := cfg.unmarshal(val, &v); err != nil {
+			return zero, fmt.Errorf("decode %s: %w", key, err)
+		}
+		return v, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return zero, fmt.Errorf("get %s: %w", key, err)
+	}
+
+	v, err := loader(ctx)
+	if err != nil {
+		return zero, fmt.Errorf("load %s: %w", key, err)
+	}
+	val, err = cfg.marshal(v)
+	if err != nil {
+		return zero, fmt.Errorf("encode %s: %w", key, err)
+	}
+	if err := client.Set(ctx, key, val, ttl).Err(); err != nil {
+		return zero, fmt.Errorf("set %s: %w", key, err)
+	}
+	return v, nil
+}
+
+// SetJSON encodes v (as JSON unless WithCacheSerializer is given) and caches it at key for ttl;
+// a zero ttl means no expiry.
+func SetJSON(ctx context.Context, client redis.UniversalClient, key string, v any, ttl time.Duration, opts ...CacheOption) error {
+	cfg, err := newCacheConfig(opts)
+	if err != nil {
+		return err
+	}
+	val, err := cfg.marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", key, err)
+	}
+	if err := client.Set(ctx, key, val, ttl).Err(); err != nil {
+		return fmt.Errorf("set %s: %w", key, err)
+	}
+	return nil
+}
+
+// GetJSON decodes the value cached at key into v. A miss is not an error: it reports found=false
+// and leaves v untouched.
+func GetJSON(ctx context.Context, client redis.UniversalClient, key string, v any, opts ...CacheOption) (found bool, err error) {
+	cfg, err := newCacheConfig(opts)
+	if err != nil {
+		return false, err
+	}
+	val, err := client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get %s: %w", key, err)
+	}
+	if err := cfg.unmarshal(val, v); err != nil {
+		return false, fmt.Errorf("decode %s: %w", key, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,357 @@
+package goredisx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingLoader returns value and counts its calls.
+func countingLoader(value string) (Loader, *atomic.Int32) {
+	var calls atomic.Int32
+	return func(context.Context) ([]byte, error) {
+		calls.Add(1)
+		return []byte(value), nil
+	}, &calls
+}
+
+func TestGetOrSet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("miss loads and caches", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		loader, calls := countingLoader("loaded")
+		ctx := context.Background()
+
+		val, err := GetOrSet(ctx, client, "k", time.Minute, loader)
+		require.NoError(t, err)
+		assert.Equal(t, "loaded", string(val))
+
+		val, err = GetOrSet(ctx, client, "k", time.Minute, loader)
+		require.NoError(t, err)
+		assert.Equal(t, "loaded", string(val))
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, time.Minute, mr.TTL("k"))
+	})
+
+	t.Run("loader error not cached", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		boom := errors.New("boom")
+
+		_, err := GetOrSet(context.Background(), client, "k", time.Minute, func(context.Context) ([]byte, error) {
+			return nil, boom
+		})
+		assert.ErrorIs(t, err, boom)
+		assert.False(t, mr.Exists("k"))
+	})
+
+	t.Run("nil loader", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		_, err := GetOrSet(context.Background(), client, "k", time.Minute, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetOrSetWithReadPreference(t *testing.T) {
+	t.Parallel()
+
+	t.Run("hit served from replica", func(t *testing.T) {
+		t.Parallel()
+		_, primary := newMiniredisClient(t)
+		replicaMR, replica := newMiniredisClient(t)
+		require.NoError(t, replicaMR.Set("k", "replicated"))
+		loader, calls := countingLoader("loaded")
+
+		val, err := GetOrSetWithReadPreference(context.Background(), primary, replica, "k", time.Minute, loader)
+		require.NoError(t, err)
+		assert.Equal(t, "replicated", string(val))
+		assert.Zero(t, calls.Load())
+	})
+
+	t.Run("loader writes through primary", func(t *testing.T) {
+		t.Parallel()
+		primaryMR, primary := newMiniredisClient(t)
+		replicaMR, replica := newMiniredisClient(t)
+		loader, calls := countingLoader("loaded")
+
+		val, err := GetOrSetWithReadPreference(context.Background(), primary, replica, "k", time.Minute, loader)
+		require.NoError(t, err)
+		assert.Equal(t, "loaded", string(val))
+		assert.Equal(t, int32(1), calls.Load())
+
+		got, err := primaryMR.Get("k")
+		require.NoError(t, err)
+		assert.Equal(t, "loaded", got)
+		assert.False(t, replicaMR.Exists("k"))
+	})
+
+	t.Run("replica failure falls back to primary", func(t *testing.T) {
+		t.Parallel()
+		primaryMR, primary := newMiniredisClient(t)
+		replicaMR, replica := newMiniredisClient(t)
+		require.NoError(t, primaryMR.Set("k", "primary"))
+		replicaMR.SetError("LOADING replica is loading")
+		loader, calls := countingLoader("loaded")
+
+		val, err := GetOrSetWithReadPreference(context.Background(), primary, replica, "k", time.Minute, loader)
+		require.NoError(t, err)
+		assert.Equal(t, "primary", string(val))
+		assert.Zero(t, calls.Load())
+	})
+
+	t.Run("nil replica is a no-op preference", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		loader, _ := countingLoader("loaded")
+
+		_, err := GetOrSetWithReadPreference(context.Background(), client, nil, "k", time.Minute, loader)
+		require.NoError(t, err)
+		assert.True(t, mr.Exists("k"))
+	})
+}
+
+func TestGetOrSetWithReadPreference_Cluster(t *testing.T) {
+	addrs := os.Getenv("REDIS_CLUSTER_ADDRS")
+	if addrs == "" {
+		t.Skip("REDIS_CLUSTER_ADDRS not set - requires Redis cluster")
+	}
+
+	primary := redis.NewClusterClient(&redis.ClusterOptions{Addrs: strings.Split(addrs, ",")})
+	defer primary.Close()
+	replica := redis.NewClusterClient(&redis.ClusterOptions{Addrs: strings.Split(addrs, ","), ReadOnly: true})
+	defer replica.Close()
+
+	ctx := context.Background()
+	key := "goredisx:getorset:cluster"
+	defer primary.Del(ctx, key)
+
+	loader, calls := countingLoader("loaded")
+	val, err := GetOrSetWithReadPreference(ctx, primary, replica, key, time.Minute, loader)
+	require.NoError(t, err)
+	assert.Equal(t, "loaded", string(val))
+	assert.Equal(t, int32(1), calls.Load())
+
+	got, err := primary.Get(ctx, key).Result()
+	require.NoError(t, err)
+	assert.Equal(t, "loaded", got)
+}
+
+type cachedUser struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+}
+
+// envelopeSerializer wraps JSON in a recognisable envelope, standing in for e.g. msgpack, and
+// counts its calls.
+func envelopeSerializer() (CacheOption, *atomic.Int32, *atomic.Int32) {
+	var marshals, unmarshals atomic.Int32
+	return WithCacheSerializer(
+		func(v any) ([]byte, error) {
+			marshals.Add(1)
+			b, err := json.Marshal(v)
+			return append([]byte("custom:"), b...), err
+		},
+		func(b []byte, v any) error {
+			unmarshals.Add(1)
+			return json.Unmarshal(bytes.TrimPrefix(b, []byte("custom:")), v)
+		},
+	), &marshals, &unmarshals
+}
+
+// userLoader returns user and counts its calls.
+func userLoader(user cachedUser) (func(context.Context) (cachedUser, error), *atomic.Int32) {
+	var calls atomic.Int32
+	return func(context.Context) (cachedUser, error) {
+		calls.Add(1)
+		return user, nil
+	}, &calls
+}
+
+func TestWithCacheSerializer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("custom serializer used for both directions", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		ctx := context.Background()
+		serializer, marshals, unmarshals := envelopeSerializer()
+
+		in := cachedUser{ID: 7, Name: "ada", Roles: []string{"admin"}}
+		loader, calls := userLoader(in)
+		out, err := GetOrSetJSON(ctx, client, "user:7", time.Minute, loader, serializer)
+		require.NoError(t, err)
+		assert.Equal(t, in, out)
+		raw, err := mr.Get("user:7")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(raw, "custom:"))
+
+		out, err = GetOrSetJSON(ctx, client, "user:7", time.Minute, loader, serializer)
+		require.NoError(t, err)
+		assert.Equal(t, in, out)
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, int32(1), marshals.Load())
+		assert.Equal(t, int32(1), unmarshals.Load())
+	})
+
+	t.Run("encoding/json by default", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		loader, _ := userLoader(cachedUser{ID: 1, Name: "bob"})
+
+		_, err := GetOrSetJSON(context.Background(), client, "user:1", 0, loader)
+		require.NoError(t, err)
+		raw, err := mr.Get("user:1")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id":1,"name":"bob","roles":null}`, raw)
+	})
+
+	t.Run("nil funcs rejected", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		loader, calls := userLoader(cachedUser{})
+
+		_, err := GetOrSetJSON(context.Background(), client, "k", 0, loader, WithCacheSerializer(nil, json.Unmarshal))
+		assert.Error(t, err)
+		_, err = GetOrSetJSON(context.Background(), client, "k", 0, loader, WithCacheSerializer(json.Marshal, nil))
+		assert.Error(t, err)
+		assert.Zero(t, calls.Load())
+	})
+}
+
+func TestGetOrSetJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("miss loads and caches", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		in := cachedUser{ID: 42, Name: "grace", Roles: []string{"admin", "editor"}}
+		loader, calls := userLoader(in)
+
+		out, err := GetOrSetJSON(context.Background(), client, "user:42", time.Minute, loader)
+		require.NoError(t, err)
+		assert.Equal(t, in, out)
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, time.Minute, mr.TTL("user:42"))
+	})
+
+	t.Run("loader error not cached", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		boom := errors.New("boom")
+
+		_, err := GetOrSetJSON(context.Background(), client, "k", time.Minute, func(context.Context) (cachedUser, error) {
+			return cachedUser{}, boom
+		})
+		assert.ErrorIs(t, err, boom)
+		assert.False(t, mr.Exists("k"))
+	})
+
+	t.Run("undecodable value is an error", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		require.NoError(t, mr.Set("user:bad", "not json"))
+		loader, calls := userLoader(cachedUser{})
+
+		_, err := GetOrSetJSON(context.Background(), client, "user:bad", time.Minute, loader)
+		assert.ErrorContains(t, err, "decode user:bad")
+		assert.Zero(t, calls.Load())
+	})
+
+	t.Run("nil loader", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		_, err := GetOrSetJSON[cachedUser](context.Background(), client, "k", time.Minute, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestSetJSONGetJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trips a struct", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		ctx := context.Background()
+
+		in := cachedUser{ID: 42, Name: "grace", Roles: []string{"admin", "editor"}}
+		require.NoError(t, SetJSON(ctx, client, "user:42", in, time.Minute))
+		assert.Equal(t, time.Minute, mr.TTL("user:42"))
+
+		var out cachedUser
+		found, err := GetJSON(ctx, client, "user:42", &out)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, in, out)
+	})
+
+	t.Run("miss returns found=false", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+
+		out := cachedUser{Name: "unchanged"}
+		found, err := GetJSON(context.Background(), client, "user:missing", &out)
+		require.NoError(t, err)
+		assert.False(t, found)
+		assert.Equal(t, cachedUser{Name: "unchanged"}, out)
+	})
+
+	t.Run("undecodable value is an error", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		require.NoError(t, mr.Set("user:bad", "not json"))
+
+		var out cachedUser
+		found, err := GetJSON(context.Background(), client, "user:bad", &out)
+		assert.ErrorContains(t, err, "decode user:bad")
+		assert.False(t, found)
+	})
+
+	t.Run("custom serializer", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		ctx := context.Background()
+		serializer, marshals, unmarshals := envelopeSerializer()
+
+		in := cachedUser{ID: 7, Name: "ada", Roles: []string{"admin"}}
+		require.NoError(t, SetJSON(ctx, client, "user:7", in, time.Minute, serializer))
+		raw, err := mr.Get("user:7")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(raw, "custom:"))
+
+		var out cachedUser
+		found, err := GetJSON(ctx, client, "user:7", &out, serializer)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, in, out)
+		assert.Equal(t, int32(1), marshals.Load())
+		assert.Equal(t, int32(1), unmarshals.Load())
+
+		err = SetJSON(ctx, client, "k", 1, 0, WithCacheSerializer(nil, json.Unmarshal))
+		assert.Error(t, err)
+		_, err = GetJSON(ctx, client, "k", new(int), WithCacheSerializer(json.Marshal, nil))
+		assert.Error(t, err)
+	})
+
+	t.Run("unencodable value is an error", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+
+		err := SetJSON(context.Background(), client, "k", make(chan int), 0)
+		assert.ErrorContains(t, err, "encode k")
+		assert.False(t, mr.Exists("k"))
+	})
+}
@@ -0,0 +1,107 @@
+package goredisx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/redis/go-redis/v9/maintnotifications"
+)
+
+// ClusterOption is a functional option used to configure redis.ClusterOptions
+// when creating a Redis Cluster client.
+type ClusterOption func(*ClusterConfig) error
+
+// ClusterConfig is the value ClusterOption values configure. It embeds the redis.ClusterOptions
+// passed to go-redis, so a custom option can set any of its fields directly, e.g.
+//
+//	func(c *goredisx.ClusterConfig) error { c.RouteRandomly = true; return nil }
+//
+// Settings resolved only after all options have run are unexported and set through the With* options.
+type ClusterConfig struct {
+	*redis.ClusterOptions
+
+	masterOnlyReads bool
+}
+
+// WithClusterMaxRedirects returns a ClusterOption that sets how many MOVED/ASK redirects a command
+// follows before failing. Zero disables following redirects.
+func WithClusterMaxRedirects(n int) ClusterOption {
+	return func(o *ClusterConfig) error {
+		if n < 0 {
+			return errors.New("max redirects cannot be negative")
+		}
+		o.MaxRedirects = n
+		return nil
+	}
+}
+
+// WithClusterReadOnly returns a ClusterOption that sends read-only commands to the lowest-latency
+// node of each slot, replicas included. Reads may then observe replication lag.
+func WithClusterReadOnly() ClusterOption {
+	return func(o *ClusterConfig) error {
+		o.ReadOnly = true
+		o.RouteByLatency = true
+		return nil
+	}
+}
+
+// WithClusterMasterOnlyReads returns a ClusterOption that, when enabled, sends every command to the
+// master owning its slot, e.g. for read-your-writes within a request. It overrides replica routing
+// (WithClusterReadOnly, or RouteRandomly set through other means) regardless of option order;
+// disabled, it leaves the configured routing alone.
+func WithClusterMasterOnlyReads(enabled bool) ClusterOption {
+	return func(o *ClusterConfig) error {
+		o.masterOnlyReads = enabled
+		return nil
+	}
+}
+
+// NewClusterClient creates and returns a redis.UniversalClient for a Redis Cluster reachable through
+// addrs (seed nodes; the rest of the topology is discovered). It applies the provided ClusterOption
+// values and verifies connectivity with a Ping bounded like NewStandaloneClient's.
+func NewClusterClient(addrs []string, opts ...ClusterOption) (redis.UniversalClient, error) {
+	options, err := buildClusterOptions(addrs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	client := redis.NewClusterClient(options)
+
+	ctx, cancel := context.WithTimeout(context.Background(), max(options.DialTimeout, defaultPingTimeout))
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("redis ping failed: %w", err)
+	}
+
+	return client, nil
+}
+
+// buildClusterOptions validates the arguments of NewClusterClient and applies opts.
+func buildClusterOptions(addrs []string, opts []ClusterOption) (*redis.ClusterOptions, error) {
+	if len(addrs) == 0 {
+		return nil, errors.New("at least one addr is required")
+	}
+
+	config := &ClusterConfig{
+		ClusterOptions: &redis.ClusterOptions{
+			Addrs: addrs,
+			MaintNotificationsConfig: &maintnotifications.Config{
+				Mode: maintnotifications.ModeDisabled, // Disable maintenance notifications
+			},
+		},
+	}
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return nil, fmt.Errorf("apply option failed: %w", err)
+		}
+	}
+	if config.masterOnlyReads {
+		config.ReadOnly = false
+		config.RouteByLatency = false
+		config.RouteRandomly = false
+	}
+	return config.ClusterOptions, nil
+}
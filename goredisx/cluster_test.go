@@ -0,0 +1,127 @@
+package goredisx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithClusterMaxRedirects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		n       int
+		wantErr bool
+	}{
+		{name: "valid", n: 5},
+		{name: "zero disables redirects", n: 0},
+		{name: "negative", n: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clusterOpts := &ClusterConfig{ClusterOptions: &redis.ClusterOptions{MaxRedirects: 3}}
+			err := WithClusterMaxRedirects(tt.n)(clusterOpts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, 3, clusterOpts.MaxRedirects)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.n, clusterOpts.MaxRedirects)
+			}
+		})
+	}
+}
+
+func TestWithClusterReadOnly(t *testing.T) {
+	t.Parallel()
+
+	clusterOpts := &ClusterConfig{ClusterOptions: &redis.ClusterOptions{}}
+	require.NoError(t, WithClusterReadOnly()(clusterOpts))
+	assert.True(t, clusterOpts.ReadOnly)
+	assert.True(t, clusterOpts.RouteByLatency)
+}
+
+func TestWithClusterMasterOnlyReads(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		opts         []ClusterOption
+		wantReadOnly bool
+	}{
+		{name: "read only", opts: []ClusterOption{WithClusterReadOnly()}, wantReadOnly: true},
+		{
+			name: "master-only after read only",
+			opts: []ClusterOption{WithClusterReadOnly(), WithClusterMasterOnlyReads(true)},
+		},
+		{
+			name: "master-only before read only",
+			opts: []ClusterOption{WithClusterMasterOnlyReads(true), WithClusterReadOnly()},
+		},
+		{
+			name: "master-only overrides random routing",
+			opts: []ClusterOption{
+				func(o *ClusterConfig) error { o.ReadOnly = true; o.RouteRandomly = true; return nil },
+				WithClusterMasterOnlyReads(true),
+			},
+		},
+		{
+			name:         "disabled keeps replica routing",
+			opts:         []ClusterOption{WithClusterReadOnly(), WithClusterMasterOnlyReads(false)},
+			wantReadOnly: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clusterOpts, err := buildClusterOptions([]string{"127.0.0.1:7000"}, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReadOnly, clusterOpts.ReadOnly)
+			assert.Equal(t, tt.wantReadOnly, clusterOpts.RouteByLatency)
+			assert.False(t, clusterOpts.RouteRandomly)
+		})
+	}
+}
+
+func TestNewClusterClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("connects and applies options", func(t *testing.T) {
+		t.Parallel()
+		// miniredis answers CLUSTER SLOTS as a single-node cluster but lacks READONLY.
+		mr := miniredis.RunT(t)
+		require.NoError(t, mr.Server().Register("READONLY", func(c *server.Peer, _ string, _ []string) {
+			c.WriteOK()
+		}))
+		client, err := NewClusterClient([]string{mr.Addr()}, WithClusterMaxRedirects(1), WithClusterReadOnly())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = client.Close() })
+
+		opts := client.(*redis.ClusterClient).Options()
+		assert.Equal(t, 1, opts.MaxRedirects)
+		assert.True(t, opts.ReadOnly)
+
+		require.NoError(t, client.Set(context.Background(), "k", "v", 0).Err())
+		got, err := mr.Get("k")
+		require.NoError(t, err)
+		assert.Equal(t, "v", got)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewClusterClient(nil)
+		assert.Error(t, err)
+
+		_, err = NewClusterClient([]string{"127.0.0.1:0"}, WithClusterMaxRedirects(-1))
+		assert.ErrorContains(t, err, "apply option failed")
+	})
+}
@@ -0,0 +1,38 @@
+package goredisx
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// InstrumentedDo runs a raw command with client.Do and returns its result together with the time
+// the call took, e.g. to attach the latency to a request-scoped logger. The duration is reported
+// even when the command fails; a miss is reported as redis.Nil like Do does.
+func InstrumentedDo(ctx context.Context, client redis.UniversalClient, cmd ...interface{}) (interface{}, time.Duration, error) {
+	start := time.Now()
+	val, err := client.Do(ctx, cmd...).Result()
+	return val, time.Since(start), err
+}
+
+// WithCommandTimeout derives a context whose deadline is d from now, for a single command that
+// legitimately runs longer (or must finish sooner) than the client's default, e.g. WAIT or a Lua script.
+// go-redis only honours context deadlines on the socket when ContextTimeoutEnabled is set, and it uses
+// the earlier of the deadline and ReadTimeout, so a deadline can shorten but never extend ReadTimeout.
+// To let individual commands extend it, disable the client-wide read timeout and bound every call
+// through its context instead:
+//
+//	client, err := NewStandaloneClient(cfg, WithStandaloneRawOptions(func(o *redis.Options) {
+//		o.ContextTimeoutEnabled = true
+//		o.ReadTimeout = -1
+//	}))
+//	...
+//	cmdCtx, cancel := WithCommandTimeout(ctx, 30*time.Second)
+//	defer cancel()
+//	err = client.Wait(cmdCtx, 1, 0).Err()
+//
+// As with context.WithTimeout, callers must call cancel once the command returns.
+func WithCommandTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, d)
+}
@@ -0,0 +1,83 @@
+package goredisx
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentedDo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful command reports latency", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+
+		val, elapsed, err := InstrumentedDo(context.Background(), client, "PING")
+		require.NoError(t, err)
+		assert.Equal(t, "PONG", val)
+		assert.GreaterOrEqual(t, elapsed, time.Duration(0))
+	})
+
+	t.Run("miss returns redis.Nil", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+
+		_, _, err := InstrumentedDo(context.Background(), client, "GET", "missing")
+		assert.ErrorIs(t, err, redis.Nil)
+	})
+}
+
+func TestWithCommandTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("derived context carries deadline", func(t *testing.T) {
+		t.Parallel()
+		before := time.Now()
+		ctx, cancel := WithCommandTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.WithinRange(t, deadline, before.Add(30*time.Second), time.Now().Add(30*time.Second))
+
+		cancel()
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+
+	t.Run("earlier parent deadline wins", func(t *testing.T) {
+		t.Parallel()
+		parent, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		want, _ := parent.Deadline()
+
+		ctx, cancelCmd := WithCommandTimeout(parent, time.Minute)
+		defer cancelCmd()
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.Equal(t, want, deadline)
+	})
+
+	t.Run("deadline bounds a blocking command", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t, WithStandaloneRawOptions(func(o *redis.Options) {
+			o.ContextTimeoutEnabled = true
+			o.ReadTimeout = -1
+		}))
+
+		// Without a read timeout, BLPOP 0 would block forever; the command's deadline bounds it.
+		ctx, cancel := WithCommandTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err := client.BLPop(ctx, 0, "empty").Err()
+		var netErr net.Error
+		require.ErrorAs(t, err, &netErr)
+		assert.True(t, netErr.Timeout())
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
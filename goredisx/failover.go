@@ -0,0 +1,136 @@
+package goredisx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// FailoverOption is a functional option used to configure redis.FailoverOptions and related
+// settings when creating a Sentinel-backed failover client.
+type FailoverOption func(*FailoverConfig) error
+
+// FailoverConfig is the value FailoverOption values configure. It embeds the redis.FailoverOptions
+// passed to go-redis, so a custom option can set any of its fields directly, e.g.
+//
+//	func(c *goredisx.FailoverConfig) error { c.ReplicaOnly = true; return nil }
+//
+// Settings resolved only after all options have run are unexported and set through the With* options.
+type FailoverConfig struct {
+	*redis.FailoverOptions
+
+	masterOnlyReads bool
+}
+
+// WithFailoverCredentials returns a FailoverOption that sets the ACL username and password used on
+// the data nodes (master and replicas). Use WithSentinelUsername and WithSentinelPassword for the
+// Sentinels themselves.
+func WithFailoverCredentials(username, password string) FailoverOption {
+	return func(o *FailoverConfig) error {
+		o.Username = username
+		o.Password = password
+		return nil
+	}
+}
+
+// WithSentinelUsername returns a FailoverOption that sets the ACL username sent to the Sentinels.
+// It is independent of the data-node credentials; go-redis only uses it together with a
+// Sentinel password.
+func WithSentinelUsername(user string) FailoverOption {
+	return func(o *FailoverConfig) error {
+		if user == "" {
+			return errors.New("sentinel username cannot be empty")
+		}
+		o.SentinelUsername = user
+		return nil
+	}
+}
+
+// WithSentinelPassword returns a FailoverOption that sets the password sent to the Sentinels,
+// either their requirepass or, with WithSentinelUsername, the ACL user's password.
+func WithSentinelPassword(pass string) FailoverOption {
+	return func(o *FailoverConfig) error {
+		if pass == "" {
+			return errors.New("sentinel password cannot be empty")
+		}
+		o.SentinelPassword = pass
+		return nil
+	}
+}
+
+// WithFailoverRouteByLatency returns a FailoverOption that sends read-only commands to the
+// lowest-latency node, replicas included. Reads may then observe replication lag.
+func WithFailoverRouteByLatency() FailoverOption {
+	return func(o *FailoverConfig) error {
+		o.RouteByLatency = true
+		return nil
+	}
+}
+
+// WithFailoverMasterOnlyReads returns a FailoverOption that, when enabled, pins every command to
+// the master, e.g. for read-your-writes within a request. It overrides replica routing
+// (WithFailoverRouteByLatency, or ReplicaOnly/RouteRandomly set through other means) regardless of
+// option order; disabled, it leaves the configured routing alone.
+func WithFailoverMasterOnlyReads(enabled bool) FailoverOption {
+	return func(o *FailoverConfig) error {
+		o.masterOnlyReads = enabled
+		return nil
+	}
+}
+
+// NewFailoverClient creates and returns a redis.UniversalClient for the master named masterName,
+// discovered through the Sentinels at sentinelAddrs. It applies the provided FailoverOption values
+// and verifies connectivity with a Ping bounded like NewStandaloneClient's. When reads are routed
+// to replicas (by latency or randomly) the client is a failover cluster client, as go-redis requires.
+func NewFailoverClient(masterName string, sentinelAddrs []string, opts ...FailoverOption) (redis.UniversalClient, error) {
+	options, err := buildFailoverOptions(masterName, sentinelAddrs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var client redis.UniversalClient
+	if options.RouteByLatency || options.RouteRandomly {
+		client = redis.NewFailoverClusterClient(options)
+	} else {
+		client = redis.NewFailoverClient(options)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), max(options.DialTimeout, defaultPingTimeout))
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("redis ping failed: %w", err)
+	}
+
+	return client, nil
+}
+
+// buildFailoverOptions validates the arguments of NewFailoverClient and applies opts.
+func buildFailoverOptions(masterName string, sentinelAddrs []string, opts []FailoverOption) (*redis.FailoverOptions, error) {
+	if masterName == "" {
+		return nil, errors.New("master name is required")
+	}
+	if len(sentinelAddrs) == 0 {
+		return nil, errors.New("at least one sentinel addr is required")
+	}
+
+	config := &FailoverConfig{
+		FailoverOptions: &redis.FailoverOptions{
+			MasterName:    masterName,
+			SentinelAddrs: sentinelAddrs,
+		},
+	}
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return nil, fmt.Errorf("apply option failed: %w", err)
+		}
+	}
+	if config.masterOnlyReads {
+		config.ReplicaOnly = false
+		config.RouteByLatency = false
+		config.RouteRandomly = false
+	}
+	return config.FailoverOptions, nil
+}
@@ -0,0 +1,183 @@
+package goredisx
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeSentinel starts a miniredis answering the SENTINEL subcommands go-redis needs, reporting
+// master as the address of the master named name.
+func newFakeSentinel(t *testing.T, name string, master *miniredis.Miniredis) *miniredis.Miniredis {
+	t.Helper()
+	host, port, err := net.SplitHostPort(master.Addr())
+	require.NoError(t, err)
+
+	sentinel := miniredis.RunT(t)
+	require.NoError(t, sentinel.Server().Register("SENTINEL", func(c *server.Peer, _ string, args []string) {
+		switch {
+		case len(args) == 2 && strings.EqualFold(args[0], "get-master-addr-by-name") && args[1] == name:
+			c.WriteLen(2)
+			c.WriteBulk(host)
+			c.WriteBulk(port)
+		case len(args) == 2 && strings.EqualFold(args[0], "get-master-addr-by-name"):
+			c.WriteNull()
+		default:
+			c.WriteLen(0)
+		}
+	}))
+	return sentinel
+}
+
+func TestWithSentinelCredentials(t *testing.T) {
+	t.Parallel()
+
+	t.Run("populate sentinel fields only", func(t *testing.T) {
+		t.Parallel()
+		failoverOpts := &FailoverConfig{FailoverOptions: &redis.FailoverOptions{Username: "app", Password: "app-pass"}}
+		require.NoError(t, WithSentinelUsername("sentinel-user")(failoverOpts))
+		require.NoError(t, WithSentinelPassword("sentinel-pass")(failoverOpts))
+
+		assert.Equal(t, "sentinel-user", failoverOpts.SentinelUsername)
+		assert.Equal(t, "sentinel-pass", failoverOpts.SentinelPassword)
+		assert.Equal(t, "app", failoverOpts.Username)
+		assert.Equal(t, "app-pass", failoverOpts.Password)
+	})
+
+	t.Run("data-node credentials leave sentinel fields alone", func(t *testing.T) {
+		t.Parallel()
+		failoverOpts := &FailoverConfig{FailoverOptions: &redis.FailoverOptions{SentinelUsername: "sentinel-user", SentinelPassword: "sentinel-pass"}}
+		require.NoError(t, WithFailoverCredentials("app", "app-pass")(failoverOpts))
+
+		assert.Equal(t, "app", failoverOpts.Username)
+		assert.Equal(t, "app-pass", failoverOpts.Password)
+		assert.Equal(t, "sentinel-user", failoverOpts.SentinelUsername)
+		assert.Equal(t, "sentinel-pass", failoverOpts.SentinelPassword)
+	})
+
+	t.Run("empty values rejected", func(t *testing.T) {
+		t.Parallel()
+		assert.Error(t, WithSentinelUsername("")(&FailoverConfig{FailoverOptions: &redis.FailoverOptions{}}))
+		assert.Error(t, WithSentinelPassword("")(&FailoverConfig{FailoverOptions: &redis.FailoverOptions{}}))
+	})
+}
+
+func TestWithFailoverMasterOnlyReads(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		opts            []FailoverOption
+		wantLatency     bool
+		wantReplicaOnly bool
+	}{
+		{name: "route by latency", opts: []FailoverOption{WithFailoverRouteByLatency()}, wantLatency: true},
+		{
+			name: "master-only after route by latency",
+			opts: []FailoverOption{WithFailoverRouteByLatency(), WithFailoverMasterOnlyReads(true)},
+		},
+		{
+			name: "master-only before route by latency",
+			opts: []FailoverOption{WithFailoverMasterOnlyReads(true), WithFailoverRouteByLatency()},
+		},
+		{
+			name: "master-only overrides replica-only",
+			opts: []FailoverOption{
+				func(o *FailoverConfig) error { o.ReplicaOnly = true; o.RouteRandomly = true; return nil },
+				WithFailoverMasterOnlyReads(true),
+			},
+		},
+		{
+			name:        "disabled keeps replica routing",
+			opts:        []FailoverOption{WithFailoverRouteByLatency(), WithFailoverMasterOnlyReads(false)},
+			wantLatency: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			failoverOpts, err := buildFailoverOptions("mymaster", []string{"127.0.0.1:26379"}, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLatency, failoverOpts.RouteByLatency)
+			assert.Equal(t, tt.wantReplicaOnly, failoverOpts.ReplicaOnly)
+			if !tt.wantLatency {
+				assert.False(t, failoverOpts.RouteRandomly)
+			}
+		})
+	}
+}
+
+func TestNewFailoverClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("authenticates sentinels and data nodes separately", func(t *testing.T) {
+		t.Parallel()
+		master := miniredis.RunT(t)
+		master.RequireUserAuth("app", "app-pass")
+		sentinel := newFakeSentinel(t, "mymaster", master)
+		sentinel.RequireUserAuth("sentinel-user", "sentinel-pass")
+
+		client, err := NewFailoverClient("mymaster", []string{sentinel.Addr()},
+			WithFailoverCredentials("app", "app-pass"),
+			WithSentinelUsername("sentinel-user"),
+			WithSentinelPassword("sentinel-pass"),
+		)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = client.Close() })
+
+		require.NoError(t, client.Set(context.Background(), "k", "v", 0).Err())
+		got, err := master.Get("k")
+		require.NoError(t, err)
+		assert.Equal(t, "v", got)
+	})
+
+	t.Run("wrong sentinel password fails", func(t *testing.T) {
+		t.Parallel()
+		master := miniredis.RunT(t)
+		sentinel := newFakeSentinel(t, "mymaster", master)
+		sentinel.RequireUserAuth("sentinel-user", "sentinel-pass")
+
+		_, err := NewFailoverClient("mymaster", []string{sentinel.Addr()},
+			WithSentinelUsername("sentinel-user"),
+			WithSentinelPassword("wrong"),
+		)
+		assert.ErrorContains(t, err, "redis ping failed")
+	})
+
+	t.Run("replica routing builds a failover cluster client", func(t *testing.T) {
+		t.Parallel()
+		master := miniredis.RunT(t)
+		sentinel := newFakeSentinel(t, "mymaster", master)
+
+		client, err := NewFailoverClient("mymaster", []string{sentinel.Addr()}, WithFailoverRouteByLatency())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = client.Close() })
+		assert.IsType(t, &redis.ClusterClient{}, client)
+
+		pinned, err := NewFailoverClient("mymaster", []string{sentinel.Addr()},
+			WithFailoverRouteByLatency(), WithFailoverMasterOnlyReads(true))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = pinned.Close() })
+		assert.IsType(t, &redis.Client{}, pinned)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewFailoverClient("", []string{"127.0.0.1:0"})
+		assert.Error(t, err)
+
+		_, err = NewFailoverClient("mymaster", nil)
+		assert.Error(t, err)
+
+		_, err = NewFailoverClient("mymaster", []string{"127.0.0.1:0"}, WithSentinelPassword(""))
+		assert.ErrorContains(t, err, "apply option failed")
+	})
+}
@@ -0,0 +1,34 @@
+package goredisx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// HSetStruct stores the fields of the struct (or pointer to struct) v tagged `redis:"field"` in the
+// hash at key. Untagged fields are skipped, and fields absent from v are left in the hash as is.
+func HSetStruct(ctx context.Context, client redis.UniversalClient, key string, v any) error {
+	if err := client.HSet(ctx, key, v).Err(); err != nil {
+		return fmt.Errorf("hset %s: %w", key, err)
+	}
+	return nil
+}
+
+// HGetStruct loads the hash at key into the `redis:"field"` tagged fields of the struct pointer v.
+// A missing hash is not an error: it reports found=false and leaves v untouched.
+func HGetStruct(ctx context.Context, client redis.UniversalClient, key string, v any) (found bool, err error) {
+	cmd := client.HGetAll(ctx, key)
+	fields, err := cmd.Result()
+	if err != nil {
+		return false, fmt.Errorf("hgetall %s: %w", key, err)
+	}
+	if len(fields) == 0 {
+		return false, nil
+	}
+	if err := cmd.Scan(v); err != nil {
+		return false, fmt.Errorf("scan %s: %w", key, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,72 @@
+package goredisx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type hashedAccount struct {
+	ID       int64   `redis:"id"`
+	Email    string  `redis:"email"`
+	Balance  float64 `redis:"balance"`
+	Verified bool    `redis:"verified"`
+	Scratch  string  // untagged, never stored
+}
+
+func TestHSetStructHGetStruct(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trips a tagged struct", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		ctx := context.Background()
+
+		in := hashedAccount{ID: 9, Email: "ada@example.com", Balance: 12.5, Verified: true, Scratch: "local"}
+		require.NoError(t, HSetStruct(ctx, client, "account:9", &in))
+		assert.Equal(t, "ada@example.com", mr.HGet("account:9", "email"))
+		fields, err := mr.HKeys("account:9")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"id", "email", "balance", "verified"}, fields)
+
+		var out hashedAccount
+		found, err := HGetStruct(ctx, client, "account:9", &out)
+		require.NoError(t, err)
+		assert.True(t, found)
+		in.Scratch = ""
+		assert.Equal(t, in, out)
+	})
+
+	t.Run("missing hash returns found=false", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+
+		out := hashedAccount{Email: "unchanged"}
+		found, err := HGetStruct(context.Background(), client, "account:missing", &out)
+		require.NoError(t, err)
+		assert.False(t, found)
+		assert.Equal(t, hashedAccount{Email: "unchanged"}, out)
+	})
+
+	t.Run("unparsable field is an error", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		mr.HSet("account:bad", "id", "not-a-number")
+
+		var out hashedAccount
+		_, err := HGetStruct(context.Background(), client, "account:bad", &out)
+		assert.ErrorContains(t, err, "scan account:bad")
+	})
+
+	t.Run("wrong type is an error", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		require.NoError(t, mr.Set("account:string", "v"))
+
+		var out hashedAccount
+		_, err := HGetStruct(context.Background(), client, "account:string", &out)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,164 @@
+package goredisx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrCircuitOpen is returned without contacting Redis while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("redis circuit breaker is open")
+
+// retryableErrorHook retries single commands whose error is accepted by retryable.
+type retryableErrorHook struct {
+	retryable  func(error) bool
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+var _ redis.Hook = (*retryableErrorHook)(nil)
+
+// newRetryableErrorHook builds the hook from the client's (already initialised) retry settings.
+func newRetryableErrorHook(retryable func(error) bool, o *redis.Options) *retryableErrorHook {
+	return &retryableErrorHook{
+		retryable:  retryable,
+		maxRetries: o.MaxRetries,
+		minBackoff: o.MinRetryBackoff,
+		maxBackoff: o.MaxRetryBackoff,
+	}
+}
+
+func (h *retryableErrorHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h *retryableErrorHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		for attempt := 0; attempt < h.maxRetries; attempt++ {
+			if err == nil || ctx.Err() != nil || !h.retryable(err) {
+				return err
+			}
+			if sleepErr := sleepContext(ctx, h.backoff(attempt)); sleepErr != nil {
+				return err
+			}
+			err = next(ctx, cmd)
+		}
+		return err
+	}
+}
+
+func (h *retryableErrorHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+// backoff returns an exponential delay for the given attempt, bounded by maxBackoff.
+func (h *retryableErrorHook) backoff(attempt int) time.Duration {
+	if h.minBackoff <= 0 {
+		return 0
+	}
+	d := h.minBackoff << attempt
+	if d <= 0 || (h.maxBackoff > 0 && d > h.maxBackoff) {
+		return h.maxBackoff
+	}
+	return d
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// circuitBreakerHook short-circuits commands with ErrCircuitOpen for cooldown after threshold
+// consecutive failures. Once the cooldown has elapsed commands are let through again: a success
+// closes the breaker, another failure re-opens it immediately.
+type circuitBreakerHook struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+var _ redis.Hook = (*circuitBreakerHook)(nil)
+
+func newCircuitBreakerHook(threshold int, cooldown time.Duration) *circuitBreakerHook {
+	return &circuitBreakerHook{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+func (h *circuitBreakerHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h *circuitBreakerHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if !h.allow() {
+			cmd.SetErr(ErrCircuitOpen)
+			return ErrCircuitOpen
+		}
+		err := next(ctx, cmd)
+		h.record(err)
+		return err
+	}
+}
+
+func (h *circuitBreakerHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if !h.allow() {
+			for _, cmd := range cmds {
+				cmd.SetErr(ErrCircuitOpen)
+			}
+			return ErrCircuitOpen
+		}
+		err := next(ctx, cmds)
+		h.record(err)
+		return err
+	}
+}
+
+// allow reports whether a command may be sent.
+func (h *circuitBreakerHook) allow() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.failures < h.threshold || !h.now().Before(h.openUntil)
+}
+
+// record updates the consecutive failure count with the outcome of a command.
+func (h *circuitBreakerHook) record(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !isBreakerFailure(err) {
+		h.failures = 0
+		return
+	}
+	h.failures++
+	if h.failures >= h.threshold {
+		h.openUntil = h.now().Add(h.cooldown)
+	}
+}
+
+// isBreakerFailure reports whether err indicates a degraded server. Misses, error replies
+// (e.g. WRONGTYPE) and callers cancelling their own context prove nothing about server health.
+func isBreakerFailure(err error) bool {
+	if err == nil || errors.Is(err, redis.Nil) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var redisErr redis.Error
+	return !errors.As(err, &redisErr)
+}
@@ -0,0 +1,175 @@
+package goredisx
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registerFlaky registers a FLAKY command on srv that fails with a PROXYMOVED error
+// the first failures times and replies OK afterwards.
+func registerFlaky(t *testing.T, srv *server.Server, failures int32) *atomic.Int32 {
+	t.Helper()
+	var calls atomic.Int32
+	require.NoError(t, srv.Register("FLAKY", func(c *server.Peer, _ string, _ []string) {
+		if calls.Add(1) <= failures {
+			c.WriteError("PROXYMOVED slot migrating, try again")
+			return
+		}
+		c.WriteOK()
+	}))
+	return &calls
+}
+
+func isProxyMoved(err error) bool {
+	return strings.HasPrefix(err.Error(), "PROXYMOVED")
+}
+
+func TestWithStandaloneRetryableError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("predicate consulted and command retried", func(t *testing.T) {
+		t.Parallel()
+		var consulted atomic.Int32
+		mr, client := newMiniredisClient(t, WithStandaloneRetryableError(func(err error) bool {
+			consulted.Add(1)
+			return isProxyMoved(err)
+		}))
+		calls := registerFlaky(t, mr.Server(), 2)
+
+		err := client.Do(context.Background(), "FLAKY").Err()
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), calls.Load())
+		assert.Equal(t, int32(2), consulted.Load())
+	})
+
+	t.Run("non retryable error returned immediately", func(t *testing.T) {
+		t.Parallel()
+		var consulted atomic.Int32
+		mr, client := newMiniredisClient(t, WithStandaloneRetryableError(func(error) bool {
+			consulted.Add(1)
+			return false
+		}))
+		calls := registerFlaky(t, mr.Server(), 2)
+
+		err := client.Do(context.Background(), "FLAKY").Err()
+		assert.ErrorContains(t, err, "PROXYMOVED")
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, int32(1), consulted.Load())
+	})
+
+	t.Run("retries bounded by max retries", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t,
+			WithStandaloneMaxRetries(1),
+			WithStandaloneRetryableError(isProxyMoved),
+		)
+		calls := registerFlaky(t, mr.Server(), 5)
+
+		err := client.Do(context.Background(), "FLAKY").Err()
+		assert.ErrorContains(t, err, "PROXYMOVED")
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("without option error is not retried", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		calls := registerFlaky(t, mr.Server(), 1)
+
+		err := client.Do(context.Background(), "FLAKY").Err()
+		assert.ErrorContains(t, err, "PROXYMOVED")
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("nil predicate rejected", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewStandaloneClient(RedisConfig{Addr: "localhost:6379"}, WithStandaloneRetryableError(nil))
+		assert.ErrorContains(t, err, "retryable predicate cannot be nil")
+	})
+}
+
+func TestWithStandaloneCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	noRetries := WithStandaloneRawOptions(func(o *redis.Options) { o.MaxRetries = -1 })
+
+	t.Run("opens after threshold and recovers after cooldown", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t, noRetries, WithStandaloneCircuitBreaker(3, 200*time.Millisecond))
+		ctx := context.Background()
+
+		mr.Close()
+		for i := 0; i < 3; i++ {
+			err := client.Ping(ctx).Err()
+			require.Error(t, err)
+			assert.NotErrorIs(t, err, ErrCircuitOpen)
+		}
+		require.NoError(t, mr.Restart())
+
+		assert.ErrorIs(t, client.Ping(ctx).Err(), ErrCircuitOpen)
+		_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Get(ctx, "k")
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+
+		require.Eventually(t, func() bool {
+			return client.Ping(ctx).Err() == nil
+		}, 2*time.Second, 50*time.Millisecond)
+	})
+
+	t.Run("error replies and misses do not trip", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t, noRetries, WithStandaloneCircuitBreaker(2, time.Minute))
+		ctx := context.Background()
+
+		for i := 0; i < 3; i++ {
+			assert.ErrorIs(t, client.Get(ctx, "missing").Err(), redis.Nil)
+		}
+		mr.SetError("WRONGTYPE Operation against a key holding the wrong kind of value")
+		for i := 0; i < 3; i++ {
+			assert.NotErrorIs(t, client.Get(ctx, "k").Err(), ErrCircuitOpen)
+		}
+		mr.SetError("")
+		assert.NoError(t, client.Ping(ctx).Err())
+	})
+
+	t.Run("failure after cooldown re-opens immediately", func(t *testing.T) {
+		t.Parallel()
+		now := time.Unix(0, 0)
+		h := newCircuitBreakerHook(2, time.Second)
+		h.now = func() time.Time { return now }
+		failure := errors.New("dial tcp: connection refused")
+
+		h.record(failure)
+		assert.True(t, h.allow())
+		h.record(failure)
+		assert.False(t, h.allow())
+
+		now = now.Add(time.Second)
+		assert.True(t, h.allow())
+		h.record(failure)
+		assert.False(t, h.allow())
+
+		now = now.Add(time.Second)
+		h.record(nil)
+		assert.True(t, h.allow())
+		h.record(failure)
+		assert.True(t, h.allow())
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		t.Parallel()
+		o := &StandaloneConfig{Options: &redis.Options{}}
+		assert.Error(t, WithStandaloneCircuitBreaker(0, time.Second)(o))
+		assert.Error(t, WithStandaloneCircuitBreaker(1, 0)(o))
+	})
+}
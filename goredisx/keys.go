@@ -0,0 +1,82 @@
+package goredisx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// deleteScanCount is the COUNT hint for each SCAN page of DeleteByPattern.
+const deleteScanCount = 500
+
+// DeleteByPattern removes every key matching pattern (SCAN MATCH syntax, e.g. "cache:user:*") and
+// returns how many were deleted. It pages through the keyspace with SCAN instead of the blocking
+// KEYS and frees each page with pipelined single-key UNLINKs, checking ctx between pages; on
+// cancellation it returns the count deleted so far with ctx's error. Keys written while it runs
+// may be missed. A cluster client is scanned on every master, and because each UNLINK names one
+// key, pages that span hash slots do not fail with CROSSSLOT.
+func DeleteByPattern(ctx context.Context, client redis.UniversalClient, pattern string) (int64, error) {
+	if pattern == "" {
+		return 0, errors.New("pattern cannot be empty")
+	}
+
+	cluster, ok := client.(*redis.ClusterClient)
+	if !ok {
+		return deleteByPattern(ctx, client, pattern)
+	}
+	var deleted atomic.Int64
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+		n, err := deleteByPattern(ctx, node, pattern)
+		deleted.Add(n)
+		return err
+	})
+	return deleted.Load(), err
+}
+
+// deleteByPattern runs the SCAN/UNLINK loop of DeleteByPattern against a single node.
+func deleteByPattern(ctx context.Context, client redis.Cmdable, pattern string) (int64, error) {
+	var deleted int64
+	var cursor uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+
+		keys, next, err := client.Scan(ctx, cursor, pattern, deleteScanCount).Result()
+		if err != nil {
+			return deleted, fmt.Errorf("scan %s: %w", pattern, err)
+		}
+		if len(keys) > 0 {
+			n, err := unlinkEach(ctx, client, keys)
+			deleted += n
+			if err != nil {
+				return deleted, fmt.Errorf("unlink %s: %w", pattern, err)
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return deleted, nil
+		}
+	}
+}
+
+// unlinkEach unlinks keys one per command in a single pipeline, so keys never need to share a slot.
+func unlinkEach(ctx context.Context, client redis.Cmdable, keys []string) (int64, error) {
+	cmds, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Unlink(ctx, key)
+		}
+		return nil
+	})
+	var deleted int64
+	for _, cmd := range cmds {
+		if c, ok := cmd.(*redis.IntCmd); ok {
+			deleted += c.Val()
+		}
+	}
+	return deleted, err
+}
@@ -0,0 +1,108 @@
+package goredisx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteByPattern(t *testing.T) {
+	t.Parallel()
+
+	t.Run("removes only matching keys", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		// More keys than one SCAN page holds.
+		for i := 0; i < 1200; i++ {
+			require.NoError(t, mr.Set(fmt.Sprintf("cache:user:%d", i), "v"))
+		}
+		require.NoError(t, mr.Set("cache:order:1", "v"))
+		require.NoError(t, mr.Set("session:1", "v"))
+
+		n, err := DeleteByPattern(context.Background(), client, "cache:user:*")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1200), n)
+		assert.ElementsMatch(t, []string{"cache:order:1", "session:1"}, mr.Keys())
+	})
+
+	t.Run("never sends multi-key unlink", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		rejectMultiKeyUnlink(mr)
+		for i := 0; i < 10; i++ {
+			require.NoError(t, mr.Set(fmt.Sprintf("cache:%d", i), "v"))
+		}
+
+		n, err := DeleteByPattern(context.Background(), client, "cache:*")
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), n)
+		assert.Empty(t, mr.Keys())
+	})
+
+	t.Run("cluster client", func(t *testing.T) {
+		t.Parallel()
+		// miniredis answers CLUSTER SLOTS as a single-node cluster, so keys land in many slots of one master.
+		mr := miniredis.RunT(t)
+		rejectMultiKeyUnlink(mr)
+		client := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{mr.Addr()}})
+		t.Cleanup(func() { _ = client.Close() })
+		for i := 0; i < 50; i++ {
+			require.NoError(t, mr.Set(fmt.Sprintf("cache:user:%d", i), "v"))
+		}
+		require.NoError(t, mr.Set("session:1", "v"))
+
+		n, err := DeleteByPattern(context.Background(), client, "cache:user:*")
+		require.NoError(t, err)
+		assert.Equal(t, int64(50), n)
+		assert.Equal(t, []string{"session:1"}, mr.Keys())
+	})
+
+	t.Run("no match deletes nothing", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		require.NoError(t, mr.Set("session:1", "v"))
+
+		n, err := DeleteByPattern(context.Background(), client, "cache:*")
+		require.NoError(t, err)
+		assert.Zero(t, n)
+		assert.True(t, mr.Exists("session:1"))
+	})
+
+	t.Run("cancelled ctx stops before deleting", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		require.NoError(t, mr.Set("cache:1", "v"))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		n, err := DeleteByPattern(ctx, client, "cache:*")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Zero(t, n)
+		assert.True(t, mr.Exists("cache:1"))
+	})
+
+	t.Run("empty pattern", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		_, err := DeleteByPattern(context.Background(), client, "")
+		assert.Error(t, err)
+	})
+}
+
+// rejectMultiKeyUnlink makes mr fail UNLINK with several keys the way a cluster does when they span slots.
+func rejectMultiKeyUnlink(mr *miniredis.Miniredis) {
+	mr.Server().SetPreHook(func(c *server.Peer, cmd string, args ...string) bool {
+		if strings.EqualFold(cmd, "UNLINK") && len(args) > 1 {
+			c.WriteError("CROSSSLOT Keys in request don't hash to the same slot")
+			return true
+		}
+		return false
+	})
+}
@@ -0,0 +1,85 @@
+package goredisx
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+// poolStatsCollector exports redis.PoolStats as Prometheus metrics on every scrape.
+type poolStatsCollector struct {
+	client redis.UniversalClient
+
+	hits       *prometheus.Desc
+	misses     *prometheus.Desc
+	timeouts   *prometheus.Desc
+	waits      *prometheus.Desc
+	waitTime   *prometheus.Desc
+	totalConns *prometheus.Desc
+	idleConns  *prometheus.Desc
+	staleConns *prometheus.Desc
+}
+
+// NewPoolStatsCollector returns a prometheus.Collector reporting the connection pool stats of client
+// as goredisx_pool_* metrics. constLabels distinguish several clients registered with the same registry.
+func NewPoolStatsCollector(client redis.UniversalClient, constLabels prometheus.Labels) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("goredisx", "pool", name), help, nil, constLabels)
+	}
+	return &poolStatsCollector{
+		client:     client,
+		hits:       desc("hits_total", "Number of times a free connection was found in the pool."),
+		misses:     desc("misses_total", "Number of times a free connection was not found in the pool."),
+		timeouts:   desc("timeouts_total", "Number of times a wait for a connection timed out."),
+		waits:      desc("waits_total", "Number of times a connection was waited for."),
+		waitTime:   desc("wait_duration_seconds_total", "Total time spent waiting for a connection."),
+		totalConns: desc("conns", "Number of connections in the pool."),
+		idleConns:  desc("idle_conns", "Number of idle connections in the pool."),
+		staleConns: desc("stale_conns_total", "Number of stale connections removed from the pool."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *poolStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.timeouts
+	ch <- c.waits
+	ch <- c.waitTime
+	ch <- c.totalConns
+	ch <- c.idleConns
+	ch <- c.staleConns
+}
+
+// Collect implements prometheus.Collector.
+func (c *poolStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.client.PoolStats()
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.timeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(c.waits, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(c.waitTime, prometheus.CounterValue, float64(stats.WaitDurationNs)/1e9)
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stats.TotalConns))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stats.IdleConns))
+	ch <- prometheus.MustNewConstMetric(c.staleConns, prometheus.CounterValue, float64(stats.StaleConns))
+}
+
+// registerPoolStats registers a pool stats collector for client, replacing a collector already
+// registered for the same addr and db.
+func registerPoolStats(reg prometheus.Registerer, client *redis.Client) error {
+	opts := client.Options()
+	collector := NewPoolStatsCollector(client, prometheus.Labels{
+		"addr": opts.Addr,
+		"db":   strconv.Itoa(opts.DB),
+	})
+
+	err := reg.Register(collector)
+	var are prometheus.AlreadyRegisteredError
+	if errors.As(err, &are) {
+		reg.Unregister(are.ExistingCollector)
+		err = reg.Register(collector)
+	}
+	return err
+}
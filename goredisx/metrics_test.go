@@ -0,0 +1,64 @@
+package goredisx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithStandaloneMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("collector metrics gathered", func(t *testing.T) {
+		t.Parallel()
+		reg := prometheus.NewPedanticRegistry()
+		mr, client := newMiniredisClient(t, WithStandaloneMetrics(reg))
+		require.NoError(t, client.Set(context.Background(), "k", "v", 0).Err())
+
+		families, err := reg.Gather()
+		require.NoError(t, err)
+		values := map[string]float64{}
+		for _, mf := range families {
+			for _, m := range mf.GetMetric() {
+				labels := map[string]string{}
+				for _, lp := range m.GetLabel() {
+					labels[lp.GetName()] = lp.GetValue()
+				}
+				assert.Equal(t, map[string]string{"addr": mr.Addr(), "db": "0"}, labels)
+				values[mf.GetName()] = m.GetCounter().GetValue() + m.GetGauge().GetValue()
+			}
+		}
+		assert.Contains(t, values, "goredisx_pool_hits_total")
+		assert.Contains(t, values, "goredisx_pool_misses_total")
+		assert.Contains(t, values, "goredisx_pool_idle_conns")
+		assert.Equal(t, float64(1), values["goredisx_pool_conns"])
+	})
+
+	t.Run("double registration replaces collector", func(t *testing.T) {
+		t.Parallel()
+		reg := prometheus.NewPedanticRegistry()
+		mr, first := newMiniredisClient(t, WithStandaloneMetrics(reg))
+
+		second, err := NewStandaloneClient(RedisConfig{Addr: mr.Addr()}, WithStandaloneMetrics(reg))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = second.Close() })
+		require.NoError(t, first.Close())
+
+		count, err := testutil.GatherAndCount(reg, "goredisx_pool_conns")
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("nil registerer uses default registry", func(t *testing.T) {
+		t.Parallel()
+		o := &StandaloneConfig{Options: &redis.Options{}}
+		require.NoError(t, WithStandaloneMetrics(nil)(o))
+		assert.True(t, o.metrics)
+		assert.Equal(t, prometheus.DefaultRegisterer, o.registerer)
+	})
+}
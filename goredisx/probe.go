@@ -0,0 +1,69 @@
+package goredisx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// errNotProbed is reported by a HealthProbe until its first ping completes.
+var errNotProbed = errors.New("health probe has not completed yet")
+
+// HealthProbe pings a client in the background and keeps the outcome of the latest ping,
+// so readiness endpoints can answer from memory instead of probing Redis on every request.
+type HealthProbe struct {
+	mu  sync.RWMutex
+	err error
+}
+
+// StartHealthProbe pings client immediately and then every interval until ctx is done, each ping
+// bounded by interval. The probe reports unhealthy until the first ping succeeds.
+// It returns an error without starting the probe if interval is not positive.
+func StartHealthProbe(ctx context.Context, client redis.UniversalClient, interval time.Duration) (*HealthProbe, error) {
+	if interval <= 0 {
+		return nil, errors.New("probe interval must be positive")
+	}
+	p := &HealthProbe{err: errNotProbed}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			p.probe(ctx, client, interval)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return p, nil
+}
+
+// probe runs one ping and records its result, unless ctx was cancelled meanwhile.
+func (p *HealthProbe) probe(ctx context.Context, client redis.UniversalClient, timeout time.Duration) {
+	pingCtx, cancel := context.WithTimeout(ctx, timeout)
+	err := HealthCheck(pingCtx, client)
+	cancel()
+	if ctx.Err() != nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.err = err
+	p.mu.Unlock()
+}
+
+// Healthy reports whether the latest ping succeeded.
+func (p *HealthProbe) Healthy() bool {
+	return p.LastError() == nil
+}
+
+// LastError returns the error of the latest ping, or nil if it succeeded.
+func (p *HealthProbe) LastError() error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.err
+}
@@ -0,0 +1,71 @@
+package goredisx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartHealthProbe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("tracks server going away and returning", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t, WithStandaloneMaxRetries(0))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		probe, err := StartHealthProbe(ctx, client, 20*time.Millisecond)
+		require.NoError(t, err)
+		require.Eventually(t, probe.Healthy, time.Second, 5*time.Millisecond)
+		assert.NoError(t, probe.LastError())
+
+		mr.Close()
+		require.Eventually(t, func() bool { return !probe.Healthy() }, time.Second, 5*time.Millisecond)
+		assert.Error(t, probe.LastError())
+
+		require.NoError(t, mr.Restart())
+		require.Eventually(t, probe.Healthy, 2*time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("unhealthy until first ping", func(t *testing.T) {
+		t.Parallel()
+		probe := &HealthProbe{err: errNotProbed}
+		assert.False(t, probe.Healthy())
+		assert.ErrorIs(t, probe.LastError(), errNotProbed)
+	})
+
+	t.Run("stops on ctx cancel", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		probe, err := StartHealthProbe(ctx, client, 10*time.Millisecond)
+		require.NoError(t, err)
+		require.Eventually(t, probe.Healthy, time.Second, 5*time.Millisecond)
+		cancel()
+
+		// Give an in-flight ping time to finish, then expect no further commands.
+		time.Sleep(30 * time.Millisecond)
+		count := mr.CommandCount()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, count, mr.CommandCount())
+		assert.True(t, probe.Healthy())
+	})
+
+	t.Run("rejects non-positive interval", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		before := mr.CommandCount()
+
+		for _, interval := range []time.Duration{0, -time.Second} {
+			probe, err := StartHealthProbe(context.Background(), client, interval)
+			assert.Error(t, err)
+			assert.Nil(t, probe)
+		}
+		assert.Equal(t, before, mr.CommandCount())
+	})
+}
@@ -0,0 +1,220 @@
+package goredisx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// defaultSubscribeBuffer matches the go-redis default message channel size.
+const defaultSubscribeBuffer = 100
+
+// SubscribeOption configures the Subscription returned by Subscribe and PSubscribe.
+type SubscribeOption func(*subscribeConfig) error
+
+type subscribeConfig struct {
+	buffer int
+}
+
+// WithSubscribeBuffer sets how many messages the subscription channel buffers (default 100).
+// When the buffer is full, newly received messages are dropped rather than stalling the
+// receive goroutine, and counted in DroppedCount. Size the buffer for the largest burst
+// the consumer must absorb.
+func WithSubscribeBuffer(n int) SubscribeOption {
+	return func(c *subscribeConfig) error {
+		if n <= 0 {
+			return errors.New("subscribe buffer must be positive")
+		}
+		c.buffer = n
+		return nil
+	}
+}
+
+// Subscription delivers the messages of a Subscribe or PSubscribe call.
+type Subscription struct {
+	ch      chan *redis.Message
+	dropped atomic.Int64
+}
+
+// Channel returns the message channel. It is closed once the subscription ends.
+func (s *Subscription) Channel() <-chan *redis.Message {
+	return s.ch
+}
+
+// DroppedCount returns how many messages were dropped because the channel buffer was full,
+// e.g. to alert on a consumer that cannot keep up.
+func (s *Subscription) DroppedCount() int64 {
+	return s.dropped.Load()
+}
+
+// forward hands messages from in to the consumer without blocking, counting those that do not fit.
+func (s *Subscription) forward(in <-chan *redis.Message) {
+	defer close(s.ch)
+	for msg := range in {
+		select {
+		case s.ch <- msg:
+		default:
+			s.dropped.Add(1)
+		}
+	}
+}
+
+// Subscribe subscribes to channels and returns the Subscription delivering their messages.
+// The subscription is re-established automatically after connection loss and closed when ctx is done,
+// which also closes its channel.
+func Subscribe(ctx context.Context, client redis.UniversalClient, channels []string, opts ...SubscribeOption) (*Subscription, error) {
+	if len(channels) == 0 {
+		return nil, errors.New("at least one channel is required")
+	}
+	cfg, err := newSubscribeConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := startSubscription(ctx, client.Subscribe(ctx, channels...), cfg)
+	if err != nil {
+		return nil, fmt.Errorf("subscribe: %w", err)
+	}
+	return s, nil
+}
+
+// PSubscribe subscribes to the channels matching patterns (e.g. "cache:invalidate:*") and returns
+// the Subscription delivering their messages, with the same buffering, reconnect and ctx handling
+// as Subscribe.
+func PSubscribe(ctx context.Context, client redis.UniversalClient, patterns []string, opts ...SubscribeOption) (*Subscription, error) {
+	if len(patterns) == 0 {
+		return nil, errors.New("at least one pattern is required")
+	}
+	cfg, err := newSubscribeConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := startSubscription(ctx, client.PSubscribe(ctx, patterns...), cfg)
+	if err != nil {
+		return nil, fmt.Errorf("psubscribe: %w", err)
+	}
+	return s, nil
+}
+
+// newSubscribeConfig applies opts over the defaults.
+func newSubscribeConfig(opts []SubscribeOption) (subscribeConfig, error) {
+	cfg := subscribeConfig{buffer: defaultSubscribeBuffer}
+	for _, opt := range opts {
+		if err := opt(&cfg); err != nil {
+			return cfg, fmt.Errorf("apply option failed: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// startSubscription listens on pubsub and forwards its messages into a new Subscription.
+func startSubscription(ctx context.Context, pubsub *redis.PubSub, cfg subscribeConfig) (*Subscription, error) {
+	in, err := listen(ctx, pubsub, defaultSubscribeBuffer)
+	if err != nil {
+		return nil, err
+	}
+	s := &Subscription{ch: make(chan *redis.Message, cfg.buffer)}
+	go s.forward(in)
+	return s, nil
+}
+
+// SubscribeKeyspaceEvents subscribes to the keyevent notifications (__keyevent@<db>__:*) of the
+// database client is connected to and returns the message channel.
+// When events is non-empty it first enables notifications with CONFIG SET notify-keyspace-events
+// (e.g. "Ex" for expirations); pass "" when the server is already configured or CONFIG is disabled.
+// The subscription is re-established automatically after connection loss and closed when ctx is done,
+// which also closes the returned channel.
+//
+// Redis publishes a keyevent only on the node holding the key, so for a *redis.ClusterClient the
+// notifications are enabled and subscribed on every master known at call time (DB 0), and their
+// messages merged; masters added later are not covered. Other client types, such as *redis.Ring,
+// are rejected.
+func SubscribeKeyspaceEvents(ctx context.Context, client redis.UniversalClient, events string) (<-chan *redis.Message, error) {
+	switch c := client.(type) {
+	case *redis.Client:
+		return subscribeNodeKeyevents(ctx, c, events, c.Options().DB)
+	case *redis.ClusterClient:
+		subCtx, cancel := context.WithCancel(ctx)
+		var mu sync.Mutex
+		var chans []<-chan *redis.Message
+		err := c.ForEachMaster(subCtx, func(ctx context.Context, node *redis.Client) error {
+			ch, err := subscribeNodeKeyevents(ctx, node, events, 0)
+			if err != nil {
+				return fmt.Errorf("%s: %w", node.Options().Addr, err)
+			}
+			mu.Lock()
+			chans = append(chans, ch)
+			mu.Unlock()
+			return nil
+		})
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		return mergeMessages(subCtx, cancel, chans), nil
+	default:
+		return nil, fmt.Errorf("keyspace events are not supported for %T", client)
+	}
+}
+
+// subscribeNodeKeyevents enables notifications on node when events is non-empty and subscribes to
+// the keyevents of db there.
+func subscribeNodeKeyevents(ctx context.Context, node *redis.Client, events string, db int) (<-chan *redis.Message, error) {
+	if events != "" {
+		if err := node.ConfigSet(ctx, "notify-keyspace-events", events).Err(); err != nil {
+			return nil, fmt.Errorf("enable keyspace notifications: %w", err)
+		}
+	}
+
+	pubsub := node.PSubscribe(ctx, fmt.Sprintf("__keyevent@%d__:*", db))
+	ch, err := listen(ctx, pubsub, defaultSubscribeBuffer)
+	if err != nil {
+		return nil, fmt.Errorf("subscribe keyspace events: %w", err)
+	}
+	return ch, nil
+}
+
+// mergeMessages fans chans into one channel, closed (and cancel called) once all of them are.
+func mergeMessages(ctx context.Context, cancel context.CancelFunc, chans []<-chan *redis.Message) <-chan *redis.Message {
+	out := make(chan *redis.Message, defaultSubscribeBuffer)
+	var wg sync.WaitGroup
+	for _, ch := range chans {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for msg := range ch {
+				select {
+				case out <- msg:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		cancel()
+		close(out)
+	}()
+	return out
+}
+
+// listen waits for pubsub to be confirmed, so no message published after return is missed, and
+// returns its message channel, closing pubsub when ctx is done.
+func listen(ctx context.Context, pubsub *redis.PubSub, buffer int) (<-chan *redis.Message, error) {
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	ch := pubsub.Channel(redis.WithChannelSize(buffer))
+	go func() {
+		<-ctx.Done()
+		_ = pubsub.Close()
+	}()
+	return ch, nil
+}
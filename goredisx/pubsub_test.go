@@ -0,0 +1,293 @@
+package goredisx
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receiveMessage waits for the next message on ch, failing the test after a timeout.
+func receiveMessage[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case msg, ok := <-ch:
+		require.True(t, ok, "channel closed")
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for message")
+	}
+	var zero T
+	return zero
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("receives published messages", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sub, err := Subscribe(ctx, client, []string{"orders", "payments"})
+		require.NoError(t, err)
+
+		require.NoError(t, client.Publish(ctx, "payments", "p-1").Err())
+		msg := receiveMessage(t, sub.Channel())
+		assert.Equal(t, "payments", msg.Channel)
+		assert.Equal(t, "p-1", msg.Payload)
+	})
+
+	t.Run("burst not lost with large buffer", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		const burst = 1000
+		sub, err := Subscribe(ctx, client, []string{"burst"}, WithSubscribeBuffer(burst))
+		require.NoError(t, err)
+
+		// Publish everything before reading anything.
+		for i := 0; i < burst; i++ {
+			require.NoError(t, client.Publish(ctx, "burst", strconv.Itoa(i)).Err())
+		}
+		for i := 0; i < burst; i++ {
+			assert.Equal(t, strconv.Itoa(i), receiveMessage(t, sub.Channel()).Payload)
+		}
+		assert.Zero(t, sub.DroppedCount())
+	})
+
+	t.Run("full buffer drops and counts", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sub, err := Subscribe(ctx, client, []string{"burst"}, WithSubscribeBuffer(1))
+		require.NoError(t, err)
+
+		// Nobody drains the channel while the burst is published.
+		const burst = 50
+		for i := 0; i < burst; i++ {
+			require.NoError(t, client.Publish(ctx, "burst", strconv.Itoa(i)).Err())
+		}
+		require.Eventually(t, func() bool {
+			return sub.DroppedCount() == burst-1
+		}, 2*time.Second, 10*time.Millisecond)
+
+		// The one buffered message is the first of the burst.
+		assert.Equal(t, "0", receiveMessage(t, sub.Channel()).Payload)
+	})
+
+	t.Run("channel closed when ctx done", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		sub, err := Subscribe(ctx, client, []string{"orders"})
+		require.NoError(t, err)
+		cancel()
+
+		select {
+		case _, ok := <-sub.Channel():
+			assert.False(t, ok)
+		case <-time.After(2 * time.Second):
+			t.Fatal("channel not closed after cancel")
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+
+		_, err := Subscribe(context.Background(), client, nil)
+		assert.Error(t, err)
+
+		_, err = Subscribe(context.Background(), client, []string{"orders"}, WithSubscribeBuffer(0))
+		assert.Error(t, err)
+	})
+}
+
+func TestPSubscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("receives pattern-matched messages", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sub, err := PSubscribe(ctx, client, []string{"cache:invalidate:*"})
+		require.NoError(t, err)
+
+		require.NoError(t, client.Publish(ctx, "cache:other", "ignored").Err())
+		require.NoError(t, client.Publish(ctx, "cache:invalidate:users", "user:7").Err())
+		msg := receiveMessage(t, sub.Channel())
+		assert.Equal(t, "cache:invalidate:*", msg.Pattern)
+		assert.Equal(t, "cache:invalidate:users", msg.Channel)
+		assert.Equal(t, "user:7", msg.Payload)
+	})
+
+	t.Run("full buffer drops and counts", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sub, err := PSubscribe(ctx, client, []string{"burst:*"}, WithSubscribeBuffer(1))
+		require.NoError(t, err)
+
+		// Nobody drains the channel while the burst is published.
+		const burst = 50
+		for i := 0; i < burst; i++ {
+			require.NoError(t, client.Publish(ctx, "burst:a", strconv.Itoa(i)).Err())
+		}
+		require.Eventually(t, func() bool {
+			return sub.DroppedCount() == burst-1
+		}, 2*time.Second, 10*time.Millisecond)
+
+		assert.Equal(t, "0", receiveMessage(t, sub.Channel()).Payload)
+	})
+
+	t.Run("channel closed when ctx done", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		sub, err := PSubscribe(ctx, client, []string{"cache:*"})
+		require.NoError(t, err)
+		cancel()
+
+		select {
+		case _, ok := <-sub.Channel():
+			assert.False(t, ok)
+		case <-time.After(2 * time.Second):
+			t.Fatal("channel not closed after cancel")
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+
+		_, err := PSubscribe(context.Background(), client, nil)
+		assert.Error(t, err)
+
+		_, err = PSubscribe(context.Background(), client, []string{"cache:*"}, WithSubscribeBuffer(0))
+		assert.Error(t, err)
+	})
+}
+
+func TestSubscribeKeyspaceEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("receives keyevent messages for the client db", func(t *testing.T) {
+		t.Parallel()
+		// miniredis does not emit keyspace notifications; publish one by hand.
+		_, client := newMiniredisClient(t, WithStandaloneDB(2))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ch, err := SubscribeKeyspaceEvents(ctx, client, "")
+		require.NoError(t, err)
+
+		require.NoError(t, client.Publish(ctx, "__keyevent@2__:expired", "session:42").Err())
+		msg := receiveMessage(t, ch)
+		assert.Equal(t, "__keyevent@2__:expired", msg.Channel)
+		assert.Equal(t, "session:42", msg.Payload)
+	})
+
+	t.Run("channel closed when ctx done", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		ch, err := SubscribeKeyspaceEvents(ctx, client, "")
+		require.NoError(t, err)
+		cancel()
+
+		select {
+		case _, ok := <-ch:
+			assert.False(t, ok)
+		case <-time.After(2 * time.Second):
+			t.Fatal("channel not closed after cancel")
+		}
+	})
+
+	t.Run("cluster client subscribes on every master", func(t *testing.T) {
+		t.Parallel()
+		// miniredis answers CLUSTER SLOTS as a single-node cluster, so its one node is the master.
+		mr := miniredis.RunT(t)
+		client := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{mr.Addr()}})
+		t.Cleanup(func() { _ = client.Close() })
+		ctx, cancel := context.WithCancel(context.Background())
+
+		ch, err := SubscribeKeyspaceEvents(ctx, client, "")
+		require.NoError(t, err)
+
+		mr.Publish("__keyevent@0__:expired", "session:42")
+		msg := receiveMessage(t, ch)
+		assert.Equal(t, "__keyevent@0__:expired", msg.Channel)
+		assert.Equal(t, "session:42", msg.Payload)
+
+		cancel()
+		select {
+		case _, ok := <-ch:
+			assert.False(t, ok)
+		case <-time.After(2 * time.Second):
+			t.Fatal("channel not closed after cancel")
+		}
+	})
+
+	t.Run("unsupported client", func(t *testing.T) {
+		t.Parallel()
+		mr := miniredis.RunT(t)
+		ring := redis.NewRing(&redis.RingOptions{Addrs: map[string]string{"a": mr.Addr()}})
+		t.Cleanup(func() { _ = ring.Close() })
+
+		_, err := SubscribeKeyspaceEvents(context.Background(), ring, "")
+		assert.ErrorContains(t, err, "not supported")
+	})
+
+	t.Run("config set failure", func(t *testing.T) {
+		t.Parallel()
+		// miniredis does not implement CONFIG.
+		_, client := newMiniredisClient(t)
+
+		_, err := SubscribeKeyspaceEvents(context.Background(), client, "Ex")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "enable keyspace notifications")
+	})
+}
+
+func TestSubscribeKeyspaceEvents_Integration(t *testing.T) {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		t.Skip("REDIS_ADDR not set - requires Redis server")
+	}
+
+	client, err := NewStandaloneClient(RedisConfig{Addr: addr})
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := SubscribeKeyspaceEvents(ctx, client, "E$")
+	require.NoError(t, err)
+
+	require.NoError(t, client.Set(ctx, "goredisx:keyspace:test", "v", time.Minute).Err())
+	defer client.Del(ctx, "goredisx:keyspace:test")
+
+	msg := receiveMessage(t, ch)
+	assert.Equal(t, "__keyevent@0__:set", msg.Channel)
+	assert.Equal(t, "goredisx:keyspace:test", msg.Payload)
+}
@@ -0,0 +1,35 @@
+package goredisx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// PreloadScripts loads each script into the server's script cache with SCRIPT LOAD, so later
+// EvalSha calls succeed without falling back to EVAL. It stops at and returns the first error.
+// Call it at startup and again after a server restart or SCRIPT FLUSH.
+func PreloadScripts(ctx context.Context, client redis.UniversalClient, scripts ...*redis.Script) error {
+	for i, script := range scripts {
+		if script == nil {
+			return fmt.Errorf("preload script %d: script is nil", i)
+		}
+		if err := script.Load(ctx, client).Err(); err != nil {
+			return fmt.Errorf("preload script %d (%s): %w", i, script.Hash(), err)
+		}
+	}
+	return nil
+}
+
+// EvalReadOnly runs a read-only script with EVALSHA_RO (falling back to EVAL_RO on NOSCRIPT), which
+// failover and cluster clients may route to replicas. Servers older than Redis 7 do not know the
+// _RO variants; for them the script runs with EVALSHA/EVAL instead, on the primary.
+// The script must not write: Redis rejects writes from EVAL_RO.
+func EvalReadOnly(ctx context.Context, client redis.UniversalClient, script *redis.Script, keys []string, args ...interface{}) *redis.Cmd {
+	cmd := script.RunRO(ctx, client, keys, args...)
+	if redis.HasErrorPrefix(cmd.Err(), "unknown command") {
+		return script.Run(ctx, client, keys, args...)
+	}
+	return cmd
+}
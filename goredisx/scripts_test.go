@@ -0,0 +1,115 @@
+package goredisx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreloadScripts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("script SHAs resolvable afterwards", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		ctx := context.Background()
+		incr := redis.NewScript(`return redis.call("INCRBY", KEYS[1], ARGV[1])`)
+		get := redis.NewScript(`return redis.call("GET", KEYS[1])`)
+
+		exists, err := client.ScriptExists(ctx, incr.Hash(), get.Hash()).Result()
+		require.NoError(t, err)
+		assert.Equal(t, []bool{false, false}, exists)
+
+		require.NoError(t, PreloadScripts(ctx, client, incr, get))
+
+		exists, err = client.ScriptExists(ctx, incr.Hash(), get.Hash()).Result()
+		require.NoError(t, err)
+		assert.Equal(t, []bool{true, true}, exists)
+
+		n, err := client.EvalSha(ctx, incr.Hash(), []string{"counter"}, 5).Int()
+		require.NoError(t, err)
+		assert.Equal(t, 5, n)
+	})
+
+	t.Run("first error returned", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		ctx := context.Background()
+		valid := redis.NewScript(`return 1`)
+		broken := redis.NewScript(`return (`)
+		never := redis.NewScript(`return 2`)
+
+		err := PreloadScripts(ctx, client, valid, broken, never)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "preload script 1")
+
+		exists, err := client.ScriptExists(ctx, valid.Hash(), never.Hash()).Result()
+		require.NoError(t, err)
+		assert.Equal(t, []bool{true, false}, exists)
+	})
+
+	t.Run("nil script", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		assert.Error(t, PreloadScripts(context.Background(), client, nil))
+	})
+}
+
+func TestEvalReadOnly(t *testing.T) {
+	t.Parallel()
+
+	get := redis.NewScript(`return redis.call("GET", KEYS[1])`)
+
+	t.Run("read-only script runs with _RO commands", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		require.NoError(t, mr.Set("greeting", "hello"))
+		var roCalls atomic.Int32
+		mr.Server().SetPreHook(func(_ *server.Peer, cmd string, _ ...string) bool {
+			if strings.HasSuffix(strings.ToUpper(cmd), "_RO") {
+				roCalls.Add(1)
+			}
+			return false
+		})
+
+		got, err := EvalReadOnly(context.Background(), client, get, []string{"greeting"}).Text()
+		require.NoError(t, err)
+		assert.Equal(t, "hello", got)
+		// EVALSHA_RO misses the script cache, then EVAL_RO runs it.
+		assert.Equal(t, int32(2), roCalls.Load())
+	})
+
+	t.Run("falls back to EVAL without _RO support", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		require.NoError(t, mr.Set("greeting", "hello"))
+		// Behave like a pre-7.0 server.
+		mr.Server().SetPreHook(func(c *server.Peer, cmd string, _ ...string) bool {
+			if strings.HasSuffix(strings.ToUpper(cmd), "_RO") {
+				c.WriteError(fmt.Sprintf("ERR unknown command '%s'", cmd))
+				return true
+			}
+			return false
+		})
+
+		got, err := EvalReadOnly(context.Background(), client, get, []string{"greeting"}).Text()
+		require.NoError(t, err)
+		assert.Equal(t, "hello", got)
+	})
+
+	t.Run("writes rejected", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t)
+		set := redis.NewScript(`return redis.call("SET", KEYS[1], "x")`)
+
+		assert.Error(t, EvalReadOnly(context.Background(), client, set, []string{"k"}).Err())
+		assert.False(t, mr.Exists("k"))
+	})
+}
@@ -0,0 +1,90 @@
+package goredisx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// semaphorePollInterval is the longest Acquire waits between attempts while all permits are held.
+const semaphorePollInterval = 50 * time.Millisecond
+
+// semaphoreAcquire evicts holders whose lease expired and takes a permit if one is free.
+// Members of the sorted set are holder tokens scored by their lease expiry in server milliseconds.
+// KEYS[1] = set, ARGV[1] = permits, ARGV[2] = ttl ms, ARGV[3] = token.
+var semaphoreAcquire = redis.NewScript(`
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now)
+if redis.call("ZCARD", KEYS[1]) >= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call("ZADD", KEYS[1], now + tonumber(ARGV[2]), ARGV[3])
+redis.call("PEXPIRE", KEYS[1], ARGV[2])
+return 1
+`)
+
+// Semaphore limits how many holders across all instances may run at once, e.g. to cap concurrent
+// exports. Each permit is leased for ttl: a holder that crashes without releasing frees its permit
+// once the lease expires, so ttl must exceed the longest time a permit is held.
+type Semaphore struct {
+	client  redis.UniversalClient
+	key     string
+	permits int
+	ttl     time.Duration
+}
+
+// NewSemaphore returns a semaphore with permits slots stored in the sorted set at key.
+// Invalid arguments are reported by Acquire.
+func NewSemaphore(client redis.UniversalClient, key string, permits int, ttl time.Duration) *Semaphore {
+	return &Semaphore{client: client, key: key, permits: permits, ttl: ttl}
+}
+
+// Acquire blocks until a permit is free or ctx is done, in which case it returns ctx's error.
+// The returned release frees the permit; calling it more than once is a no-op.
+func (s *Semaphore) Acquire(ctx context.Context) (release func(), err error) {
+	switch {
+	case s.key == "":
+		return nil, errors.New("semaphore key cannot be empty")
+	case s.permits < 1:
+		return nil, errors.New("semaphore permits must be at least 1")
+	case s.ttl < time.Millisecond:
+		return nil, errors.New("semaphore ttl must be at least 1ms")
+	}
+
+	token := uuid.NewString()
+	wait := min(semaphorePollInterval, s.ttl)
+	for {
+		ok, err := semaphoreAcquire.Run(ctx, s.client, []string{s.key}, s.permits, s.ttl.Milliseconds(), token).Bool()
+		if err != nil {
+			return nil, fmt.Errorf("acquire semaphore %s: %w", s.key, err)
+		}
+		if ok {
+			return s.releaser(token), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// releaser returns a func removing token from the set once. It does not take the Acquire ctx,
+// which may be cancelled by the time the holder is done; an expired lease needs no release.
+func (s *Semaphore) releaser(token string) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), defaultPingTimeout)
+			defer cancel()
+			_ = s.client.ZRem(ctx, s.key, token).Err()
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package goredisx
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemaphore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("permits+1-th acquire blocks until a release", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		sem := NewSemaphore(client, "sem:exports", 2, time.Minute)
+		ctx := context.Background()
+
+		release1, err := sem.Acquire(ctx)
+		require.NoError(t, err)
+		release2, err := sem.Acquire(ctx)
+		require.NoError(t, err)
+		defer release2()
+
+		var acquired atomic.Bool
+		done := make(chan func())
+		go func() {
+			release, err := sem.Acquire(ctx)
+			assert.NoError(t, err)
+			acquired.Store(true)
+			done <- release
+		}()
+
+		time.Sleep(150 * time.Millisecond)
+		assert.False(t, acquired.Load(), "third acquire must wait for a free permit")
+
+		release1()
+		release1() // no-op
+		select {
+		case release := <-done:
+			release()
+		case <-time.After(2 * time.Second):
+			t.Fatal("third acquire not granted after release")
+		}
+	})
+
+	t.Run("expired lease frees the permit", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		sem := NewSemaphore(client, "sem:crashy", 1, 100*time.Millisecond)
+
+		// The first holder never releases.
+		_, err := sem.Acquire(context.Background())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		release, err := sem.Acquire(ctx)
+		require.NoError(t, err)
+		release()
+	})
+
+	t.Run("ctx done while waiting", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		sem := NewSemaphore(client, "sem:busy", 1, time.Minute)
+		release, err := sem.Acquire(context.Background())
+		require.NoError(t, err)
+		defer release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err = sem.Acquire(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		ctx := context.Background()
+
+		_, err := NewSemaphore(client, "", 1, time.Minute).Acquire(ctx)
+		assert.Error(t, err)
+		_, err = NewSemaphore(client, "sem", 0, time.Minute).Acquire(ctx)
+		assert.Error(t, err)
+		_, err = NewSemaphore(client, "sem", 1, 0).Acquire(ctx)
+		assert.Error(t, err)
+	})
+}
+
+func TestSemaphore_Integration(t *testing.T) {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		t.Skip("REDIS_ADDR not set - requires Redis server")
+	}
+
+	client, err := NewStandaloneClient(RedisConfig{Addr: addr})
+	require.NoError(t, err)
+	defer client.Close()
+	defer client.Del(context.Background(), "goredisx:semaphore:test")
+
+	sem := NewSemaphore(client, "goredisx:semaphore:test", 1, time.Minute)
+	release, err := sem.Acquire(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err = sem.Acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	release, err = sem.Acquire(context.Background())
+	require.NoError(t, err)
+	release()
+}
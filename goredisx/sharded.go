@@ -0,0 +1,99 @@
+package goredisx
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// shardVirtualNodes is the number of ring points per node; more points even out the key spread.
+const shardVirtualNodes = 160
+
+// ShardedClient spreads keys over independent standalone Redis nodes with a consistent hash ring,
+// so adding or removing a node only remaps the keys of that node.
+// It is simple client-side sharding, not Redis Cluster: multi-key commands must stay on one node.
+type ShardedClient struct {
+	clients []redis.UniversalClient
+	ring    []uint32 // sorted hash points
+	owners  []int    // owners[i] is the index into clients for ring[i]
+}
+
+// NewShardedClient connects to every addr with NewStandaloneClient, applying opts to each node,
+// and builds the hash ring. It fails if addrs is empty, contains duplicates, or any node is unreachable.
+func NewShardedClient(addrs []string, opts ...StandaloneOption) (*ShardedClient, error) {
+	if len(addrs) == 0 {
+		return nil, errors.New("at least one addr is required")
+	}
+
+	s := &ShardedClient{}
+	seen := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		if _, dup := seen[addr]; dup {
+			_ = s.Close()
+			return nil, fmt.Errorf("duplicate addr: %s", addr)
+		}
+		seen[addr] = struct{}{}
+
+		client, err := NewStandaloneClient(RedisConfig{Addr: addr}, opts...)
+		if err != nil {
+			_ = s.Close()
+			return nil, fmt.Errorf("shard %s: %w", addr, err)
+		}
+		s.clients = append(s.clients, client)
+	}
+	s.buildRing(addrs)
+	return s, nil
+}
+
+// buildRing places shardVirtualNodes points per node, keyed by addr so the mapping does not
+// depend on the order of addrs.
+func (s *ShardedClient) buildRing(addrs []string) {
+	type point struct {
+		hash  uint32
+		owner int
+	}
+	points := make([]point, 0, len(addrs)*shardVirtualNodes)
+	for i, addr := range addrs {
+		for v := 0; v < shardVirtualNodes; v++ {
+			points = append(points, point{hash: crc32.ChecksumIEEE([]byte(addr + "#" + strconv.Itoa(v))), owner: i})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].hash < points[j].hash })
+
+	s.ring = make([]uint32, len(points))
+	s.owners = make([]int, len(points))
+	for i, p := range points {
+		s.ring[i] = p.hash
+		s.owners[i] = p.owner
+	}
+}
+
+// ForKey returns the client of the node owning key.
+func (s *ShardedClient) ForKey(key string) redis.UniversalClient {
+	h := crc32.ChecksumIEEE([]byte(key))
+	i := sort.Search(len(s.ring), func(i int) bool { return s.ring[i] >= h })
+	if i == len(s.ring) {
+		i = 0
+	}
+	return s.clients[s.owners[i]]
+}
+
+// Clients returns the clients of all nodes, e.g. for health checks or fan-out commands.
+func (s *ShardedClient) Clients() []redis.UniversalClient {
+	return append([]redis.UniversalClient(nil), s.clients...)
+}
+
+// Close closes every node client, returning the joined errors.
+func (s *ShardedClient) Close() error {
+	var errs []error
+	for _, client := range s.clients {
+		if err := client.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,111 @@
+package goredisx
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newShardNodes starts n in-memory Redis servers and returns their addresses.
+func newShardNodes(t *testing.T, n int) []string {
+	t.Helper()
+	addrs := make([]string, n)
+	for i := range addrs {
+		addrs[i] = miniredis.RunT(t).Addr()
+	}
+	return addrs
+}
+
+func shardAddr(client redis.UniversalClient) string {
+	return client.(*redis.Client).Options().Addr
+}
+
+func TestNewShardedClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("same key maps to the same node", func(t *testing.T) {
+		t.Parallel()
+		addrs := newShardNodes(t, 3)
+		s, err := NewShardedClient(addrs)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = s.Close() })
+
+		for i := 0; i < 100; i++ {
+			key := fmt.Sprintf("user:%d", i)
+			assert.Equal(t, shardAddr(s.ForKey(key)), shardAddr(s.ForKey(key)))
+		}
+
+		// Mapping is independent of the order addrs are given in.
+		reversed, err := NewShardedClient([]string{addrs[2], addrs[1], addrs[0]})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = reversed.Close() })
+		for i := 0; i < 100; i++ {
+			key := fmt.Sprintf("user:%d", i)
+			assert.Equal(t, shardAddr(s.ForKey(key)), shardAddr(reversed.ForKey(key)))
+		}
+	})
+
+	t.Run("keys distribute across nodes", func(t *testing.T) {
+		t.Parallel()
+		addrs := newShardNodes(t, 3)
+		s, err := NewShardedClient(addrs)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = s.Close() })
+
+		counts := map[string]int{}
+		for i := 0; i < 3000; i++ {
+			counts[shardAddr(s.ForKey(fmt.Sprintf("user:%d", i)))]++
+		}
+		require.Len(t, counts, 3)
+		for addr, n := range counts {
+			assert.Greater(t, n, 500, "node %s owns too few keys", addr)
+		}
+	})
+
+	t.Run("writes land on the owning node", func(t *testing.T) {
+		t.Parallel()
+		addrs := newShardNodes(t, 2)
+		s, err := NewShardedClient(addrs)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = s.Close() })
+		ctx := context.Background()
+
+		require.NoError(t, s.ForKey("k").Set(ctx, "k", "v", 0).Err())
+		for _, client := range s.Clients() {
+			n, err := client.Exists(ctx, "k").Result()
+			require.NoError(t, err)
+			want := int64(0)
+			if shardAddr(client) == shardAddr(s.ForKey("k")) {
+				want = 1
+			}
+			assert.Equal(t, want, n)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewShardedClient(nil)
+		assert.Error(t, err)
+
+		addrs := newShardNodes(t, 1)
+		_, err = NewShardedClient([]string{addrs[0], addrs[0]})
+		assert.ErrorContains(t, err, "duplicate addr")
+	})
+
+	t.Run("unreachable node fails", func(t *testing.T) {
+		t.Parallel()
+		addrs := newShardNodes(t, 1)
+		mr := miniredis.RunT(t)
+		dead := mr.Addr()
+		mr.Close()
+
+		_, err := NewShardedClient([]string{addrs[0], dead})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), dead)
+	})
+}
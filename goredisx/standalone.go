@@ -5,8 +5,14 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/kwstars/go-bootstrap/closerx"
+	"github.com/kwstars/go-bootstrap/internal/secret"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/v9"
 	"github.com/redis/go-redis/v9/maintnotifications"
 )
@@ -15,6 +21,15 @@ import (
 // using the go-redis v9 library, with a set of functional options for
 // configuring standalone Redis instances.
 
+// defaultPingTimeout bounds the startup ping unless a longer DialTimeout is configured.
+const defaultPingTimeout = 5 * time.Second
+
+// Settings applied by WithStandaloneStaleCheck.
+const (
+	staleCheckMaxIdleTime = time.Minute
+	staleCheckPoolTimeout = 2 * time.Second
+)
+
 // RedisConfig holds parameters for connecting to a standalone Redis server.
 type RedisConfig struct {
 	Addr     string
@@ -34,13 +49,39 @@ func (c *RedisConfig) Validate() error {
 	return nil
 }
 
-// StandaloneOption is a functional option used to configure redis.Options
+// String renders the configuration for diagnostics with the password masked.
+func (c RedisConfig) String() string {
+	return fmt.Sprintf("RedisConfig{Addr:%s DB:%d Username:%s Password:%s}",
+		c.Addr, c.DB, c.Username, secret.Mask(c.Password))
+}
+
+// StandaloneOption is a functional option used to configure redis.Options and related settings
 // when creating a standalone Redis client.
-type StandaloneOption func(*redis.Options) error
+type StandaloneOption func(*StandaloneConfig) error
+
+// StandaloneConfig is the value StandaloneOption values configure. It embeds the redis.Options
+// passed to go-redis, so a custom option can set any of its fields directly, e.g.
+//
+//	func(c *goredisx.StandaloneConfig) error { c.PoolFIFO = true; return nil }
+//
+// Settings with no redis.Options field, such as hooks that can only be installed once the client
+// exists, are unexported and set through the With* options.
+type StandaloneConfig struct {
+	*redis.Options
+
+	retryable      func(error) bool
+	connectTries   int
+	connectBackoff time.Duration
+	rawOptions     []func(*redis.Options)
+	metrics        bool
+	registerer     prometheus.Registerer
+	breaker        *circuitBreakerHook
+	aclCompat      bool
+}
 
 // WithStandaloneAddr returns a StandaloneOption that sets the Redis server address.
 func WithStandaloneAddr(addr string) StandaloneOption {
-	return func(o *redis.Options) error {
+	return func(o *StandaloneConfig) error {
 		if addr == "" {
 			return errors.New("addr cannot be empty")
 		}
@@ -51,7 +92,7 @@ func WithStandaloneAddr(addr string) StandaloneOption {
 
 // WithStandaloneDB returns a StandaloneOption that sets the Redis database number.
 func WithStandaloneDB(db int) StandaloneOption {
-	return func(o *redis.Options) error {
+	return func(o *StandaloneConfig) error {
 		if db < 0 {
 			return errors.New("db must be non-negative")
 		}
@@ -62,15 +103,25 @@ func WithStandaloneDB(db int) StandaloneOption {
 
 // WithStandaloneUsername returns a StandaloneOption that sets the Redis username.
 func WithStandaloneUsername(username string) StandaloneOption {
-	return func(o *redis.Options) error {
+	return func(o *StandaloneConfig) error {
 		o.Username = username
 		return nil
 	}
 }
 
+// WithStandaloneACLCompat returns a StandaloneOption that authenticates as the "default" user when a
+// password is configured without a username, as Redis 6+ ACL deployments expect, instead of relying on
+// legacy single-argument AUTH. It takes effect after all options, so their order does not matter.
+func WithStandaloneACLCompat() StandaloneOption {
+	return func(o *StandaloneConfig) error {
+		o.aclCompat = true
+		return nil
+	}
+}
+
 // WithPassword returns a StandaloneOption that sets the Redis password.
 func WithPassword(password string) StandaloneOption {
-	return func(o *redis.Options) error {
+	return func(o *StandaloneConfig) error {
 		o.Password = password
 		return nil
 	}
@@ -78,7 +129,7 @@ func WithPassword(password string) StandaloneOption {
 
 // WithStandaloneDialTimeout returns a StandaloneOption that sets the dial timeout.
 func WithStandaloneDialTimeout(timeout time.Duration) StandaloneOption {
-	return func(o *redis.Options) error {
+	return func(o *StandaloneConfig) error {
 		if timeout <= 0 {
 			return errors.New("dial timeout must be positive")
 		}
@@ -89,7 +140,7 @@ func WithStandaloneDialTimeout(timeout time.Duration) StandaloneOption {
 
 // WithStandaloneReadTimeout returns a StandaloneOption that sets the read timeout.
 func WithStandaloneReadTimeout(timeout time.Duration) StandaloneOption {
-	return func(o *redis.Options) error {
+	return func(o *StandaloneConfig) error {
 		if timeout <= 0 {
 			return errors.New("read timeout must be positive")
 		}
@@ -100,7 +151,7 @@ func WithStandaloneReadTimeout(timeout time.Duration) StandaloneOption {
 
 // WithStandaloneWriteTimeout returns a StandaloneOption that sets the write timeout.
 func WithStandaloneWriteTimeout(timeout time.Duration) StandaloneOption {
-	return func(o *redis.Options) error {
+	return func(o *StandaloneConfig) error {
 		if timeout <= 0 {
 			return errors.New("write timeout must be positive")
 		}
@@ -111,7 +162,7 @@ func WithStandaloneWriteTimeout(timeout time.Duration) StandaloneOption {
 
 // WithStandalonePoolSize returns a StandaloneOption that sets the connection pool size.
 func WithStandalonePoolSize(size int) StandaloneOption {
-	return func(o *redis.Options) error {
+	return func(o *StandaloneConfig) error {
 		if size <= 0 {
 			return errors.New("pool size must be positive")
 		}
@@ -122,7 +173,7 @@ func WithStandalonePoolSize(size int) StandaloneOption {
 
 // WithStandaloneMinIdleConns returns a StandaloneOption that sets the minimum number of idle connections.
 func WithStandaloneMinIdleConns(count int) StandaloneOption {
-	return func(o *redis.Options) error {
+	return func(o *StandaloneConfig) error {
 		if count < 0 {
 			return errors.New("min idle conns cannot be negative")
 		}
@@ -133,7 +184,7 @@ func WithStandaloneMinIdleConns(count int) StandaloneOption {
 
 // WithStandalonePoolTimeout returns a StandaloneOption that sets the pool timeout.
 func WithStandalonePoolTimeout(timeout time.Duration) StandaloneOption {
-	return func(o *redis.Options) error {
+	return func(o *StandaloneConfig) error {
 		if timeout <= 0 {
 			return errors.New("pool timeout must be positive")
 		}
@@ -144,7 +195,7 @@ func WithStandalonePoolTimeout(timeout time.Duration) StandaloneOption {
 
 // WithStandaloneConnMaxIdleTime returns a StandaloneOption that sets the maximum idle time for connections.
 func WithStandaloneConnMaxIdleTime(duration time.Duration) StandaloneOption {
-	return func(o *redis.Options) error {
+	return func(o *StandaloneConfig) error {
 		if duration <= 0 {
 			return errors.New("conn max idle time must be positive")
 		}
@@ -153,9 +204,22 @@ func WithStandaloneConnMaxIdleTime(duration time.Duration) StandaloneOption {
 	}
 }
 
+// WithStandaloneStaleCheck returns a StandaloneOption presetting pool settings for networks that
+// silently drop idle connections, such as stateful firewalls and NAT gateways (which commonly
+// expire idle flows after 4-5 minutes). Connections idle for over a minute are closed instead of
+// reused, and callers wait at most 2s for a pooled connection rather than queueing behind dead ones.
+// Apply it before WithStandaloneConnMaxIdleTime or WithStandalonePoolTimeout to override either value.
+func WithStandaloneStaleCheck() StandaloneOption {
+	return func(o *StandaloneConfig) error {
+		o.ConnMaxIdleTime = staleCheckMaxIdleTime
+		o.PoolTimeout = staleCheckPoolTimeout
+		return nil
+	}
+}
+
 // WithStandaloneMaxRetries returns a StandaloneOption that sets the maximum number of retries for commands.
 func WithStandaloneMaxRetries(count int) StandaloneOption {
-	return func(o *redis.Options) error {
+	return func(o *StandaloneConfig) error {
 		if count < 0 {
 			return errors.New("max retries cannot be negative")
 		}
@@ -164,9 +228,103 @@ func WithStandaloneMaxRetries(count int) StandaloneOption {
 	}
 }
 
+// WithStandaloneRetryableError returns a StandaloneOption that additionally retries commands whose
+// error satisfies retryable (e.g. a proxy's MOVED-like reply), on top of go-redis' built-in retries.
+// Retries honour MaxRetries and the retry backoff settings of the client.
+//
+// Each hook retry re-runs go-redis' own retry loop, so for errors that go-redis also retries
+// (network errors, timeouts, LOADING and similar) the attempts multiply, up to (MaxRetries+1)²
+// per command. Keep retryable narrow to errors go-redis does not retry itself to stay at
+// MaxRetries+1. Pipelines and transactions are passed through and never retried by this option.
+func WithStandaloneRetryableError(retryable func(err error) bool) StandaloneOption {
+	return func(o *StandaloneConfig) error {
+		if retryable == nil {
+			return errors.New("retryable predicate cannot be nil")
+		}
+		o.retryable = retryable
+		return nil
+	}
+}
+
+// WithStandaloneCircuitBreaker returns a StandaloneOption that fails commands fast with ErrCircuitOpen
+// for cooldown after threshold consecutive failures (network errors and timeouts; misses and error
+// replies do not count), instead of letting them pile up against a degraded server.
+// The breaker wraps the retry logic, so one command counts once however often it was retried.
+func WithStandaloneCircuitBreaker(threshold int, cooldown time.Duration) StandaloneOption {
+	return func(o *StandaloneConfig) error {
+		if threshold < 1 {
+			return errors.New("circuit breaker threshold must be at least 1")
+		}
+		if cooldown <= 0 {
+			return errors.New("circuit breaker cooldown must be positive")
+		}
+		o.breaker = newCircuitBreakerHook(threshold, cooldown)
+		return nil
+	}
+}
+
+// WithStandaloneConnectRetry returns a StandaloneOption that makes NewStandaloneClient try the
+// startup ping up to attempts times, sleeping backoff between failures, before giving up.
+// This lets services ride out a rolling Redis restart instead of failing fast at boot.
+func WithStandaloneConnectRetry(attempts int, backoff time.Duration) StandaloneOption {
+	return func(o *StandaloneConfig) error {
+		if attempts < 1 {
+			return errors.New("connect attempts must be at least 1")
+		}
+		if backoff < 0 {
+			return errors.New("connect backoff must be non-negative")
+		}
+		o.connectTries = attempts
+		o.connectBackoff = backoff
+		return nil
+	}
+}
+
+// WithStandaloneRawOptions returns a StandaloneOption that mutates redis.Options directly, for fields
+// without a dedicated option (e.g. IdentitySuffix, DisableIdentity, UnstableResp3).
+// Raw functions run after all typed options, in the order given, so they have the final say.
+func WithStandaloneRawOptions(fn func(*redis.Options)) StandaloneOption {
+	return func(o *StandaloneConfig) error {
+		if fn == nil {
+			return errors.New("raw options func cannot be nil")
+		}
+		o.rawOptions = append(o.rawOptions, fn)
+		return nil
+	}
+}
+
+// WithStandaloneMetrics returns a StandaloneOption that registers a pool stats collector
+// (see NewPoolStatsCollector) for the client with reg once it is connected, labelled with the
+// client's addr and db. A nil reg uses prometheus.DefaultRegisterer. Registering a second client
+// with the same addr and db replaces the earlier collector instead of failing.
+func WithStandaloneMetrics(reg prometheus.Registerer) StandaloneOption {
+	return func(o *StandaloneConfig) error {
+		if reg == nil {
+			reg = prometheus.DefaultRegisterer
+		}
+		o.metrics = true
+		o.registerer = reg
+		return nil
+	}
+}
+
+// WithStandaloneProtocol returns a StandaloneOption that sets the RESP protocol version (2 or 3).
+// go-redis silently falls back to RESP2 when the server rejects HELLO 3; requesting 3 explicitly
+// makes NewStandaloneClient verify RESP3 support instead, which features such as client-side
+// caching depend on.
+func WithStandaloneProtocol(protocol int) StandaloneOption {
+	return func(o *StandaloneConfig) error {
+		if protocol != 2 && protocol != 3 {
+			return errors.New("protocol must be 2 or 3")
+		}
+		o.Protocol = protocol
+		return nil
+	}
+}
+
 // WithStandaloneTLSConfig returns a StandaloneOption that configures TLS for the client connection.
 func WithStandaloneTLSConfig(config *tls.Config) StandaloneOption {
-	return func(o *redis.Options) error {
+	return func(o *StandaloneConfig) error {
 		o.TLSConfig = config
 		return nil
 	}
@@ -174,15 +332,52 @@ func WithStandaloneTLSConfig(config *tls.Config) StandaloneOption {
 
 // WithStandaloneClientName returns a StandaloneOption that sets the client name reported to Redis.
 func WithStandaloneClientName(name string) StandaloneOption {
-	return func(o *redis.Options) error {
+	return func(o *StandaloneConfig) error {
 		o.ClientName = name
 		return nil
 	}
 }
 
+// WithStandaloneOnReconnect returns a StandaloneOption that calls fn whenever the client opens a
+// connection after its first one, e.g. to alert on an unstable link to Redis.
+// go-redis has no reconnect event, so this is a heuristic on top of OnConnect: replacing a dropped
+// connection counts, but so does the pool growing under load or refilling after idle conns were
+// reaped. Size the pool (e.g. MinIdleConns equal to PoolSize) accordingly if that matters.
+// fn runs synchronously on the dialing goroutine and should return quickly.
+func WithStandaloneOnReconnect(fn func()) StandaloneOption {
+	return func(o *StandaloneConfig) error {
+		if fn == nil {
+			return errors.New("reconnect callback cannot be nil")
+		}
+		var connected atomic.Bool
+		chainOnConnect(o.Options, func(context.Context, *redis.Conn) error {
+			if connected.Swap(true) {
+				fn()
+			}
+			return nil
+		})
+		return nil
+	}
+}
+
+// chainOnConnect appends fn to the OnConnect callback already configured on o.
+func chainOnConnect(o *redis.Options, fn func(ctx context.Context, cn *redis.Conn) error) {
+	prev := o.OnConnect
+	if prev == nil {
+		o.OnConnect = fn
+		return
+	}
+	o.OnConnect = func(ctx context.Context, cn *redis.Conn) error {
+		if err := prev(ctx, cn); err != nil {
+			return err
+		}
+		return fn(ctx, cn)
+	}
+}
+
 // NewStandaloneClient creates and returns a configured redis.UniversalClient for a standalone Redis instance.
 // It validates cfg, applies provided StandaloneOption values, constructs the client, and verifies
-// connectivity by performing a Ping using the configured DialTimeout.
+// connectivity by performing a Ping bounded by the larger of DialTimeout and a 5s default.
 func NewStandaloneClient(cfg RedisConfig, opts ...StandaloneOption) (redis.UniversalClient, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, err
@@ -200,32 +395,136 @@ func NewStandaloneClient(cfg RedisConfig, opts ...StandaloneOption) (redis.Unive
 	}
 
 	// Apply all options.
+	config := &StandaloneConfig{Options: options}
 	for _, opt := range opts {
-		if err := opt(options); err != nil {
+		if err := opt(config); err != nil {
 			return nil, fmt.Errorf("apply option failed: %w", err)
 		}
 	}
+	if config.aclCompat && options.Password != "" && options.Username == "" {
+		options.Username = "default"
+	}
+	for _, fn := range config.rawOptions {
+		fn(options)
+	}
 
 	client := redis.NewClient(options)
+	if config.breaker != nil {
+		client.AddHook(config.breaker)
+	}
+	if config.retryable != nil {
+		client.AddHook(newRetryableErrorHook(config.retryable, client.Options()))
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := client.Ping(ctx).Err(); err != nil {
+	if err := pingWithRetry(client, config); err != nil {
 		_ = client.Close()
 		return nil, fmt.Errorf("redis ping failed: %w", err)
 	}
 
+	// go-redis treats protocol 3 as a preference, so verify it when it was asked for explicitly.
+	if options.Protocol == 3 {
+		if err := probeRESP3(client); err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+	}
+
+	if config.metrics {
+		if err := registerPoolStats(config.registerer, client); err != nil {
+			_ = client.Close()
+			return nil, fmt.Errorf("register metrics failed: %w", err)
+		}
+	}
+
 	return client, nil
 }
 
+// pingWithRetry runs the startup ping up to the configured number of attempts.
+func pingWithRetry(client *redis.Client, config *StandaloneConfig) error {
+	attempts := max(config.connectTries, 1)
+	for attempt := 1; ; attempt++ {
+		err := startupPing(client)
+		if err == nil || attempt >= attempts {
+			return err
+		}
+		time.Sleep(config.connectBackoff)
+	}
+}
+
+// probeRESP3 runs HELLO 3 and reports a descriptive error if the server only speaks RESP2.
+func probeRESP3(client *redis.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), max(client.Options().DialTimeout, defaultPingTimeout))
+	defer cancel()
+
+	if err := client.Do(ctx, "HELLO", "3").Err(); err != nil {
+		return fmt.Errorf("redis server does not support RESP3 (protocol 3 requested, e.g. for client-side caching): %w", err)
+	}
+	return nil
+}
+
+// startupPing pings client once, bounded by the larger of DialTimeout and defaultPingTimeout.
+func startupPing(client *redis.Client) error {
+	// Give a long DialTimeout the chance to complete before the startup ping gives up.
+	ctx, cancel := context.WithTimeout(context.Background(), max(client.Options().DialTimeout, defaultPingTimeout))
+	defer cancel()
+
+	return client.Ping(ctx).Err()
+}
+
 // HealthCheck pings the provided Redis client and returns any error encountered.
 // It is a convenience wrapper for readiness/liveness checks.
+// An already-done ctx returns its error without a round trip.
 func HealthCheck(ctx context.Context, client redis.UniversalClient) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	return client.Ping(ctx).Err()
 }
 
+// HealthCheckRole reports the replication role of the server behind client ("master" or "slave").
+// It runs ROLE and falls back to parsing INFO replication when ROLE is rejected (e.g. by a proxy),
+// letting readiness checks distinguish a reachable primary from a reachable replica.
+func HealthCheckRole(ctx context.Context, client redis.UniversalClient) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	reply, err := client.Do(ctx, "ROLE").Slice()
+	if err == nil {
+		if len(reply) == 0 {
+			return "", errors.New("empty ROLE reply")
+		}
+		role, ok := reply[0].(string)
+		if !ok {
+			return "", fmt.Errorf("unexpected ROLE reply type %T", reply[0])
+		}
+		return role, nil
+	}
+
+	var redisErr redis.Error
+	if !errors.As(err, &redisErr) {
+		return "", err
+	}
+
+	info, infoErr := client.Info(ctx, "replication").Result()
+	if infoErr != nil {
+		return "", fmt.Errorf("redis role check failed: %w", errors.Join(err, infoErr))
+	}
+	for _, line := range strings.Split(info, "\n") {
+		if role, ok := strings.CutPrefix(strings.TrimSpace(line), "role:"); ok {
+			return role, nil
+		}
+	}
+	return "", errors.New("role not found in INFO replication")
+}
+
 // Close closes the provided Redis client, returning any error encountered.
 func Close(client redis.UniversalClient) error {
 	return client.Close()
 }
+
+// Closer returns an io.Closer that closes the provided Redis client via Close.
+func Closer(client redis.UniversalClient) io.Closer {
+	return closerx.AsCloser(func() error {
+		return Close(client)
+	})
+}
@@ -3,14 +3,33 @@ package goredisx
 import (
 	"context"
 	"crypto/tls"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// newMiniredisClient starts an in-memory Redis server and returns a client connected to it.
+func newMiniredisClient(t *testing.T, opts ...StandaloneOption) (*miniredis.Miniredis, redis.UniversalClient) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client, err := NewStandaloneClient(RedisConfig{Addr: mr.Addr()}, opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return mr, client
+}
+
 func TestRedisConfigValidate(t *testing.T) {
 	t.Parallel()
 
@@ -68,6 +87,27 @@ func TestRedisConfigValidate(t *testing.T) {
 	}
 }
 
+func TestRedisConfigString(t *testing.T) {
+	t.Parallel()
+
+	cfg := RedisConfig{
+		Addr:     "redis.internal:6380",
+		DB:       4,
+		Username: "cache",
+		Password: "s3cr3t-pass",
+	}
+
+	for _, out := range []string{cfg.String(), fmt.Sprintf("%v", cfg), fmt.Sprintf("%+v", &cfg)} {
+		assert.NotContains(t, out, "s3cr3t-pass")
+		assert.Contains(t, out, "Password:***")
+		assert.Contains(t, out, "redis.internal:6380")
+		assert.Contains(t, out, "DB:4")
+		assert.Contains(t, out, "cache")
+	}
+
+	assert.NotContains(t, RedisConfig{Addr: "localhost:6379"}.String(), "***")
+}
+
 func TestNewClient(t *testing.T) {
 	t.Skip("Skipping functional test - requires Redis server")
 
@@ -158,7 +198,7 @@ func TestWithStandaloneTLSConfig(t *testing.T) {
 	}
 
 	opt := WithStandaloneTLSConfig(tlsConfig)
-	redisOpts := &redis.Options{}
+	redisOpts := &StandaloneConfig{Options: &redis.Options{}}
 	err := opt(redisOpts)
 	assert.NoError(t, err)
 	assert.Equal(t, tlsConfig, redisOpts.TLSConfig)
@@ -169,7 +209,7 @@ func TestWithStandaloneWriteTimeout(t *testing.T) {
 
 	timeout := 15 * time.Second
 	opt := WithStandaloneWriteTimeout(timeout)
-	redisOpts := &redis.Options{}
+	redisOpts := &StandaloneConfig{Options: &redis.Options{}}
 	err := opt(redisOpts)
 	assert.NoError(t, err)
 	assert.Equal(t, timeout, redisOpts.WriteTimeout)
@@ -199,7 +239,7 @@ func TestWithStandaloneAddr(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			opt := WithStandaloneAddr(tt.addr)
-			redisOpts := &redis.Options{}
+			redisOpts := &StandaloneConfig{Options: &redis.Options{}}
 			err := opt(redisOpts)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -240,7 +280,7 @@ func TestWithStandaloneDB(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			opt := WithStandaloneDB(tt.db)
-			redisOpts := &redis.Options{}
+			redisOpts := &StandaloneConfig{Options: &redis.Options{}}
 			err := opt(redisOpts)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -257,7 +297,7 @@ func TestWithStandaloneUsername(t *testing.T) {
 
 	username := "testuser"
 	opt := WithStandaloneUsername(username)
-	redisOpts := &redis.Options{}
+	redisOpts := &StandaloneConfig{Options: &redis.Options{}}
 	err := opt(redisOpts)
 	assert.NoError(t, err)
 	assert.Equal(t, username, redisOpts.Username)
@@ -268,12 +308,57 @@ func TestWithPassword(t *testing.T) {
 
 	password := "testpass"
 	opt := WithPassword(password)
-	redisOpts := &redis.Options{}
+	redisOpts := &StandaloneConfig{Options: &redis.Options{}}
 	err := opt(redisOpts)
 	assert.NoError(t, err)
 	assert.Equal(t, password, redisOpts.Password)
 }
 
+func TestWithStandaloneACLCompat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		username string
+		compat   bool
+		want     string
+	}{
+		{name: "defaulted with compat and password", compat: true, want: "default"},
+		{name: "untouched without compat", compat: false, want: ""},
+		{name: "explicit username kept", username: "app", compat: true, want: "app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mr := miniredis.RunT(t)
+			user := tt.username
+			if user == "" {
+				user = "default"
+			}
+			mr.RequireUserAuth(user, "secret")
+
+			// The compat option comes first to show ordering does not matter.
+			var opts []StandaloneOption
+			if tt.compat {
+				opts = append(opts, WithStandaloneACLCompat())
+			}
+			opts = append(opts, WithPassword("secret"), WithStandaloneUsername(tt.username))
+
+			client, err := NewStandaloneClient(RedisConfig{Addr: mr.Addr()}, opts...)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = client.Close() })
+			assert.Equal(t, tt.want, client.(*redis.Client).Options().Username)
+		})
+	}
+
+	t.Run("no password leaves username empty", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t, WithStandaloneACLCompat())
+		assert.Empty(t, client.(*redis.Client).Options().Username)
+	})
+}
+
 func TestWithStandaloneDialTimeout(t *testing.T) {
 	t.Parallel()
 
@@ -303,7 +388,7 @@ func TestWithStandaloneDialTimeout(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			opt := WithStandaloneDialTimeout(tt.timeout)
-			redisOpts := &redis.Options{}
+			redisOpts := &StandaloneConfig{Options: &redis.Options{}}
 			err := opt(redisOpts)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -344,7 +429,7 @@ func TestWithStandaloneReadTimeout(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			opt := WithStandaloneReadTimeout(tt.timeout)
-			redisOpts := &redis.Options{}
+			redisOpts := &StandaloneConfig{Options: &redis.Options{}}
 			err := opt(redisOpts)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -385,7 +470,7 @@ func TestWithStandaloneWriteTimeoutErrors(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			opt := WithStandaloneWriteTimeout(tt.timeout)
-			redisOpts := &redis.Options{}
+			redisOpts := &StandaloneConfig{Options: &redis.Options{}}
 			err := opt(redisOpts)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -426,7 +511,7 @@ func TestWithStandalonePoolSize(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			opt := WithStandalonePoolSize(tt.size)
-			redisOpts := &redis.Options{}
+			redisOpts := &StandaloneConfig{Options: &redis.Options{}}
 			err := opt(redisOpts)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -467,7 +552,7 @@ func TestWithStandaloneMinIdleConns(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			opt := WithStandaloneMinIdleConns(tt.count)
-			redisOpts := &redis.Options{}
+			redisOpts := &StandaloneConfig{Options: &redis.Options{}}
 			err := opt(redisOpts)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -508,7 +593,7 @@ func TestWithStandalonePoolTimeout(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			opt := WithStandalonePoolTimeout(tt.timeout)
-			redisOpts := &redis.Options{}
+			redisOpts := &StandaloneConfig{Options: &redis.Options{}}
 			err := opt(redisOpts)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -549,7 +634,7 @@ func TestWithStandaloneConnMaxIdleTime(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			opt := WithStandaloneConnMaxIdleTime(tt.duration)
-			redisOpts := &redis.Options{}
+			redisOpts := &StandaloneConfig{Options: &redis.Options{}}
 			err := opt(redisOpts)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -561,6 +646,26 @@ func TestWithStandaloneConnMaxIdleTime(t *testing.T) {
 	}
 }
 
+func TestWithStandaloneStaleCheck(t *testing.T) {
+	t.Parallel()
+
+	t.Run("bounded idle time", func(t *testing.T) {
+		t.Parallel()
+		redisOpts := &StandaloneConfig{Options: &redis.Options{}}
+		require.NoError(t, WithStandaloneStaleCheck()(redisOpts))
+		assert.Positive(t, redisOpts.ConnMaxIdleTime)
+		assert.LessOrEqual(t, redisOpts.ConnMaxIdleTime, time.Minute)
+		assert.Equal(t, staleCheckPoolTimeout, redisOpts.PoolTimeout)
+	})
+
+	t.Run("later option overrides preset", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t, WithStandaloneStaleCheck(), WithStandaloneConnMaxIdleTime(10*time.Second))
+		assert.Equal(t, 10*time.Second, client.(*redis.Client).Options().ConnMaxIdleTime)
+		assert.Equal(t, staleCheckPoolTimeout, client.(*redis.Client).Options().PoolTimeout)
+	})
+}
+
 func TestWithStandaloneMaxRetries(t *testing.T) {
 	t.Parallel()
 
@@ -590,7 +695,7 @@ func TestWithStandaloneMaxRetries(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			opt := WithStandaloneMaxRetries(tt.count)
-			redisOpts := &redis.Options{}
+			redisOpts := &StandaloneConfig{Options: &redis.Options{}}
 			err := opt(redisOpts)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -607,12 +712,227 @@ func TestWithStandaloneClientName(t *testing.T) {
 
 	clientName := "my-test-client"
 	opt := WithStandaloneClientName(clientName)
-	redisOpts := &redis.Options{}
+	redisOpts := &StandaloneConfig{Options: &redis.Options{}}
 	err := opt(redisOpts)
 	assert.NoError(t, err)
 	assert.Equal(t, clientName, redisOpts.ClientName)
 }
 
+func TestWithStandaloneOnReconnect(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil callback", func(t *testing.T) {
+		t.Parallel()
+		assert.Error(t, WithStandaloneOnReconnect(nil)(&StandaloneConfig{Options: &redis.Options{}}))
+	})
+
+	t.Run("fires after dropped connection", func(t *testing.T) {
+		t.Parallel()
+		var reconnects atomic.Int32
+		mr, client := newMiniredisClient(t,
+			WithStandalonePoolSize(1),
+			WithStandaloneOnReconnect(func() { reconnects.Add(1) }),
+		)
+		ctx := context.Background()
+
+		// The startup ping opened the first connection, which is not a reconnect.
+		require.NoError(t, client.Ping(ctx).Err())
+		assert.Zero(t, reconnects.Load())
+
+		mr.Close()
+		require.NoError(t, mr.Restart())
+
+		// The pooled connection is dead; the client dials a new one.
+		assert.Eventually(t, func() bool {
+			return client.Ping(ctx).Err() == nil
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, int32(1), reconnects.Load())
+	})
+}
+
+func TestNewClient_PingTimeoutFollowsDialTimeout(t *testing.T) {
+	if testing.Short() {
+		t.Skip("waits past the default ping timeout")
+	}
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+
+	// The dialer connects only after the default ping timeout has elapsed, like a slow
+	// network path that a generous DialTimeout is meant to tolerate.
+	delay := defaultPingTimeout + 500*time.Millisecond
+	slowDial := func(o *StandaloneConfig) error {
+		o.Dialer = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(delay):
+			}
+			var d net.Dialer
+			return d.DialContext(ctx, network, mr.Addr())
+		}
+		return nil
+	}
+
+	start := time.Now()
+	client, err := NewStandaloneClient(RedisConfig{Addr: mr.Addr()},
+		WithStandaloneDialTimeout(delay+5*time.Second),
+		slowDial,
+	)
+	require.NoError(t, err)
+	defer client.Close()
+	assert.GreaterOrEqual(t, time.Since(start), delay)
+}
+
+// newFlakyPingServer starts a Redis stub whose PING fails the first failures times.
+func newFlakyPingServer(t *testing.T, failures int32) (*server.Server, *atomic.Int32) {
+	t.Helper()
+	srv, err := server.NewServer("127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(srv.Close)
+
+	var calls atomic.Int32
+	require.NoError(t, srv.Register("PING", func(c *server.Peer, _ string, _ []string) {
+		if calls.Add(1) <= failures {
+			c.WriteError("ERR server not ready")
+			return
+		}
+		c.WriteInline("PONG")
+	}))
+	return srv, &calls
+}
+
+func TestWithStandaloneConnectRetry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("client returned after retries", func(t *testing.T) {
+		t.Parallel()
+		srv, calls := newFlakyPingServer(t, 2)
+
+		client, err := NewStandaloneClient(RedisConfig{Addr: srv.Addr().String()},
+			WithStandaloneConnectRetry(3, 10*time.Millisecond))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = client.Close() })
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("error after last attempt", func(t *testing.T) {
+		t.Parallel()
+		srv, calls := newFlakyPingServer(t, 5)
+
+		client, err := NewStandaloneClient(RedisConfig{Addr: srv.Addr().String()},
+			WithStandaloneConnectRetry(2, 10*time.Millisecond))
+		require.Error(t, err)
+		assert.Nil(t, client)
+		assert.Contains(t, err.Error(), "redis ping failed")
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("single attempt without option", func(t *testing.T) {
+		t.Parallel()
+		srv, calls := newFlakyPingServer(t, 1)
+
+		_, err := NewStandaloneClient(RedisConfig{Addr: srv.Addr().String()})
+		require.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		t.Parallel()
+		o := &StandaloneConfig{Options: &redis.Options{}}
+		assert.Error(t, WithStandaloneConnectRetry(0, time.Second)(o))
+		assert.Error(t, WithStandaloneConnectRetry(1, -time.Second)(o))
+	})
+}
+
+func TestWithStandaloneRawOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("field preserved through client construction", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t, WithStandaloneRawOptions(func(o *redis.Options) {
+			o.IdentitySuffix = "-orders"
+			o.DisableIdentity = true
+		}))
+
+		opts := client.(*redis.Client).Options()
+		assert.Equal(t, "-orders", opts.IdentitySuffix)
+		assert.True(t, opts.DisableIdentity)
+	})
+
+	t.Run("runs after typed options", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t,
+			WithStandaloneRawOptions(func(o *redis.Options) { o.ClientName = "raw" }),
+			WithStandaloneClientName("typed"),
+		)
+
+		assert.Equal(t, "raw", client.(*redis.Client).Options().ClientName)
+	})
+
+	t.Run("nil func", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewStandaloneClient(RedisConfig{Addr: "localhost:6379"}, WithStandaloneRawOptions(nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "apply option failed")
+	})
+}
+
+func TestWithStandaloneProtocol(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RESP3 server accepted", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t, WithStandaloneProtocol(3))
+		assert.Equal(t, 3, client.(*redis.Client).Options().Protocol)
+	})
+
+	t.Run("RESP2-only server rejected when protocol 3 requested", func(t *testing.T) {
+		t.Parallel()
+		// The stub knows PING but not HELLO, like a RESP2-only server.
+		srv, _ := newFlakyPingServer(t, 0)
+
+		client, err := NewStandaloneClient(RedisConfig{Addr: srv.Addr().String()}, WithStandaloneProtocol(3))
+		require.Error(t, err)
+		assert.Nil(t, client)
+		assert.Contains(t, err.Error(), "does not support RESP3")
+	})
+
+	t.Run("RESP2-only server accepted without explicit protocol", func(t *testing.T) {
+		t.Parallel()
+		srv, _ := newFlakyPingServer(t, 0)
+
+		client, err := NewStandaloneClient(RedisConfig{Addr: srv.Addr().String()})
+		require.NoError(t, err)
+		require.NoError(t, client.Close())
+	})
+
+	t.Run("invalid protocol", func(t *testing.T) {
+		t.Parallel()
+		assert.Error(t, WithStandaloneProtocol(1)(&StandaloneConfig{Options: &redis.Options{}}))
+	})
+}
+
+func TestNewClient_PingFailsOnClosedPort(t *testing.T) {
+	t.Parallel()
+
+	// Reserve a port and release it so nothing is listening there.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	dialTimeout := 3 * time.Second
+	start := time.Now()
+	client, err := NewStandaloneClient(RedisConfig{Addr: addr}, WithStandaloneDialTimeout(dialTimeout))
+	elapsed := time.Since(start)
+
+	assert.Nil(t, client)
+	require.ErrorContains(t, err, "redis ping failed")
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+	assert.Less(t, elapsed, max(dialTimeout, defaultPingTimeout))
+}
+
 func TestNewClient_WithInvalidOption(t *testing.T) {
 	t.Parallel()
 
@@ -631,3 +951,81 @@ func TestNewClient_WithInvalidOption(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "apply option failed")
 }
+
+func TestHealthCheck_CanceledContext(t *testing.T) {
+	t.Parallel()
+	mr, client := newMiniredisClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	before := mr.CommandCount()
+	assert.ErrorIs(t, HealthCheck(ctx, client), context.Canceled)
+	assert.Equal(t, before, mr.CommandCount())
+}
+
+func TestHealthCheckRole(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		reply []any
+	}{
+		{
+			name:  "master",
+			reply: []any{"master", 0, []any{}},
+		},
+		{
+			name:  "slave",
+			reply: []any{"slave", "10.0.0.1", 6379, "connected", 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mr, client := newMiniredisClient(t)
+			require.NoError(t, mr.Server().Register("ROLE", func(c *server.Peer, _ string, _ []string) {
+				c.WriteLen(len(tt.reply))
+				for _, v := range tt.reply {
+					switch v := v.(type) {
+					case string:
+						c.WriteBulk(v)
+					case int:
+						c.WriteInt(v)
+					case []any:
+						c.WriteLen(0)
+					}
+				}
+			}))
+
+			role, err := HealthCheckRole(context.Background(), client)
+			require.NoError(t, err)
+			assert.Equal(t, tt.name, role)
+		})
+	}
+}
+
+func TestHealthCheckRole_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	// miniredis supports neither ROLE nor INFO replication, so both errors surface.
+	_, client := newMiniredisClient(t)
+
+	role, err := HealthCheckRole(context.Background(), client)
+	assert.Error(t, err)
+	assert.Empty(t, role)
+	assert.Contains(t, err.Error(), "redis role check failed")
+}
+
+func TestCloser(t *testing.T) {
+	t.Parallel()
+
+	_, client := newMiniredisClient(t)
+	closer := Closer(client)
+
+	require.NoError(t, closer.Close())
+	assert.ErrorIs(t, client.Ping(context.Background()).Err(), redis.ErrClosed)
+
+	// Closing again surfaces the client's own error.
+	assert.ErrorIs(t, closer.Close(), redis.ErrClosed)
+}
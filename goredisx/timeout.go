@@ -0,0 +1,74 @@
+package goredisx
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TimeoutClient wraps a client to give individual calls their own read timeout, e.g. a tight
+// bound for hot-path GETs and a generous one for SCANs, without changing the client-wide
+// ReadTimeout. The wrapped client is not modified, so calls made on it directly behave as before.
+//
+// The timeout is applied as a context deadline, which go-redis only honours on the socket when
+// the client has ContextTimeoutEnabled (see WithCommandTimeout); it can shorten but not extend
+// ReadTimeout. Commands without a method here can go through Do.
+type TimeoutClient struct {
+	client  redis.UniversalClient
+	timeout time.Duration
+}
+
+// NewTimeoutClient wraps client. Without WithReadTimeout, calls use the caller's context as is.
+func NewTimeoutClient(client redis.UniversalClient) *TimeoutClient {
+	return &TimeoutClient{client: client}
+}
+
+// WithReadTimeout returns a copy of c whose calls are bounded by d; d <= 0 removes the bound.
+// The receiver is unchanged, so derived clients can be kept per call site.
+func (c *TimeoutClient) WithReadTimeout(d time.Duration) *TimeoutClient {
+	return &TimeoutClient{client: c.client, timeout: d}
+}
+
+// Do runs a raw command within the configured timeout.
+func (c *TimeoutClient) Do(ctx context.Context, args ...interface{}) *redis.Cmd {
+	ctx, cancel := c.context(ctx)
+	defer cancel()
+	return c.client.Do(ctx, args...)
+}
+
+// Get runs GET within the configured timeout.
+func (c *TimeoutClient) Get(ctx context.Context, key string) *redis.StringCmd {
+	ctx, cancel := c.context(ctx)
+	defer cancel()
+	return c.client.Get(ctx, key)
+}
+
+// Set runs SET within the configured timeout.
+func (c *TimeoutClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	ctx, cancel := c.context(ctx)
+	defer cancel()
+	return c.client.Set(ctx, key, value, expiration)
+}
+
+// Del runs DEL within the configured timeout.
+func (c *TimeoutClient) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	ctx, cancel := c.context(ctx)
+	defer cancel()
+	return c.client.Del(ctx, keys...)
+}
+
+// Scan runs a single SCAN step within the configured timeout.
+func (c *TimeoutClient) Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+	ctx, cancel := c.context(ctx)
+	defer cancel()
+	return c.client.Scan(ctx, cursor, match, count)
+}
+
+// context derives the per-call context, or returns ctx unchanged when no timeout is set.
+func (c *TimeoutClient) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
@@ -0,0 +1,86 @@
+package goredisx
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withContextTimeouts makes the client honour context deadlines on the socket.
+func withContextTimeouts() StandaloneOption {
+	return WithStandaloneRawOptions(func(o *redis.Options) {
+		o.ContextTimeoutEnabled = true
+	})
+}
+
+// slowDown delays every command naming key by delay before the server handles it.
+func slowDown(srv *server.Server, key string, delay time.Duration) {
+	srv.SetPreHook(func(_ *server.Peer, _ string, args ...string) bool {
+		if len(args) > 0 && args[0] == key {
+			time.Sleep(delay)
+		}
+		return false
+	})
+}
+
+func TestTimeoutClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("tiny per-call timeout trips on slow command", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t, withContextTimeouts())
+		slowDown(mr.Server(), "slow", 200*time.Millisecond)
+		ctx := context.Background()
+
+		start := time.Now()
+		err := NewTimeoutClient(client).WithReadTimeout(20*time.Millisecond).Get(ctx, "slow").Err()
+		var netErr net.Error
+		require.ErrorAs(t, err, &netErr)
+		assert.True(t, netErr.Timeout())
+		assert.Less(t, time.Since(start), 200*time.Millisecond)
+	})
+
+	t.Run("generous timeout lets slow command finish", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t, withContextTimeouts())
+		require.NoError(t, mr.Set("slow", "value"))
+		slowDown(mr.Server(), "slow", 50*time.Millisecond)
+
+		got, err := NewTimeoutClient(client).WithReadTimeout(time.Second).Get(context.Background(), "slow").Result()
+		require.NoError(t, err)
+		assert.Equal(t, "value", got)
+	})
+
+	t.Run("base client and wrapper without timeout unchanged", func(t *testing.T) {
+		t.Parallel()
+		mr, client := newMiniredisClient(t, withContextTimeouts())
+		slowDown(mr.Server(), "slow", 50*time.Millisecond)
+		ctx := context.Background()
+
+		tc := NewTimeoutClient(client)
+		// Deriving a bounded copy leaves tc unbounded.
+		_ = tc.WithReadTimeout(time.Millisecond)
+		require.NoError(t, tc.Set(ctx, "slow", "v", 0).Err())
+		assert.Equal(t, "v", client.Get(ctx, "slow").Val())
+	})
+
+	t.Run("commands pass through", func(t *testing.T) {
+		t.Parallel()
+		_, client := newMiniredisClient(t)
+		ctx := context.Background()
+		tc := NewTimeoutClient(client).WithReadTimeout(time.Second)
+
+		require.NoError(t, tc.Set(ctx, "a", "1", 0).Err())
+		assert.Equal(t, "PONG", tc.Do(ctx, "PING").Val())
+		keys, _, err := tc.Scan(ctx, 0, "*", 10).Result()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a"}, keys)
+		assert.Equal(t, int64(1), tc.Del(ctx, "a").Val())
+	})
+}
@@ -5,10 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/kwstars/go-bootstrap/closerx"
+	"github.com/kwstars/go-bootstrap/internal/secret"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -44,6 +47,12 @@ func (c *MySQLConfig) Validate() error {
 	return nil
 }
 
+// String renders the configuration for diagnostics with the password masked.
+func (c MySQLConfig) String() string {
+	return fmt.Sprintf("MySQLConfig{Username:%s Password:%s Host:%s Port:%d Database:%s}",
+		c.Username, secret.Mask(c.Password), c.Host, c.Port, c.Database)
+}
+
 // dsnParams holds DSN query parameters.
 type dsnParams struct {
 	Charset              string
@@ -83,6 +92,19 @@ func WithPrepareStmt(prepare bool) Option {
 	}
 }
 
+// WithGormConfig mutates the gorm.Config directly, for settings without a dedicated option
+// (e.g. SkipDefaultTransaction, FullSaveAssociations, QueryFields).
+// It runs in order alongside the other options, so later options may override its changes.
+func WithGormConfig(fn func(*gorm.Config)) Option {
+	return func(cfg *gorm.Config, _ *dsnParams, _ *poolParams) error {
+		if fn == nil {
+			return errors.New("gorm config func cannot be nil")
+		}
+		fn(cfg)
+		return nil
+	}
+}
+
 // WithCharset sets the connection charset.
 func WithCharset(charset string) Option {
 	return func(_ *gorm.Config, dsn *dsnParams, _ *poolParams) error {
@@ -160,6 +182,8 @@ func WithAllowNativePasswords(allow bool) Option {
 }
 
 // WithConnectionPool sets connection pool parameters.
+// A zero value leaves the database/sql default in place: maxOpen=0 means unlimited open
+// connections, not none. Use WithUnlimitedConns to state that intent explicitly.
 func WithConnectionPool(maxOpen, maxIdle int, maxLifetime, maxIdleTime time.Duration) Option {
 	return func(_ *gorm.Config, _ *dsnParams, pool *poolParams) error {
 		if maxOpen < 0 {
@@ -176,6 +200,15 @@ func WithConnectionPool(maxOpen, maxIdle int, maxLifetime, maxIdleTime time.Dura
 	}
 }
 
+// WithUnlimitedConns removes the cap on open connections, overriding an earlier WithConnectionPool maxOpen.
+// This is the database/sql default, so the option only documents intent at the call site.
+func WithUnlimitedConns() Option {
+	return func(_ *gorm.Config, _ *dsnParams, pool *poolParams) error {
+		pool.MaxOpenConns = 0
+		return nil
+	}
+}
+
 // buildDSN constructs the MySQL DSN string.
 func buildDSN(cfg *MySQLConfig, params *dsnParams) (string, error) {
 	if err := cfg.Validate(); err != nil {
@@ -224,6 +257,8 @@ func buildDSN(cfg *MySQLConfig, params *dsnParams) (string, error) {
 }
 
 // configurePool sets connection pool parameters on the underlying sql.DB.
+// Zero values are skipped and keep the database/sql defaults; for MaxOpenConns that is 0, meaning
+// unlimited. Negative values are rejected earlier by WithConnectionPool.
 func configurePool(sqlDB *sql.DB, params *poolParams) {
 	if params.MaxOpenConns > 0 {
 		sqlDB.SetMaxOpenConns(params.MaxOpenConns)
@@ -288,7 +323,11 @@ func NewMySQLDB(cfg MySQLConfig, opts ...Option) (*gorm.DB, error) {
 }
 
 // HealthCheck verifies database connection health.
+// An already-done ctx returns its error without touching the pool.
 func HealthCheck(ctx context.Context, db *gorm.DB) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	sqlDB, err := db.DB()
 	if err != nil {
 		return err
@@ -304,3 +343,10 @@ func Close(db *gorm.DB) error {
 	}
 	return sqlDB.Close()
 }
+
+// Closer returns an io.Closer that closes the database connection via Close.
+func Closer(db *gorm.DB) io.Closer {
+	return closerx.AsCloser(func() error {
+		return Close(db)
+	})
+}
@@ -2,13 +2,39 @@ package gormx
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+// fakeDriver is a database/sql driver that never connects; it lets tests build a
+// *gorm.DB backed by a real *sql.DB without a MySQL server.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("fake driver cannot connect")
+}
+
+func init() {
+	sql.Register("gormx-fake", fakeDriver{})
+}
+
+// newFakeDB returns a *gorm.DB whose connection pool is an unconnected *sql.DB.
+func newFakeDB(t *testing.T) (*gorm.DB, *sql.DB) {
+	t.Helper()
+	sqlDB, err := sql.Open("gormx-fake", "")
+	require.NoError(t, err)
+	return &gorm.DB{Config: &gorm.Config{ConnPool: sqlDB}}, sqlDB
+}
+
 func TestMySQLConfigValidate(t *testing.T) {
 	t.Run("Valid config should pass", func(t *testing.T) {
 		cfg := &MySQLConfig{
@@ -70,6 +96,30 @@ func TestMySQLConfigValidate(t *testing.T) {
 	})
 }
 
+func TestMySQLConfigString(t *testing.T) {
+	cfg := MySQLConfig{
+		Username: "appuser",
+		Password: "s3cr3t-pass",
+		Host:     "db.internal",
+		Port:     3307,
+		Database: "orders",
+	}
+
+	for _, out := range []string{cfg.String(), fmt.Sprintf("%v", cfg), fmt.Sprintf("%+v", &cfg)} {
+		assert.NotContains(t, out, "s3cr3t-pass")
+		assert.Contains(t, out, "Password:***")
+		assert.Contains(t, out, "appuser")
+		assert.Contains(t, out, "db.internal")
+		assert.Contains(t, out, "3307")
+		assert.Contains(t, out, "orders")
+	}
+
+	t.Run("Empty password is not masked", func(t *testing.T) {
+		cfg := MySQLConfig{Username: "appuser"}
+		assert.NotContains(t, cfg.String(), "***")
+	})
+}
+
 func TestBuildDSN(t *testing.T) {
 	t.Run("Basic DSN construction", func(t *testing.T) {
 		cfg := &MySQLConfig{
@@ -204,6 +254,37 @@ func TestBuildDSN(t *testing.T) {
 	})
 }
 
+func TestWithGormConfig(t *testing.T) {
+	t.Run("Hook mutates the built config", func(t *testing.T) {
+		cfg := &gorm.Config{}
+		opt := WithGormConfig(func(c *gorm.Config) {
+			c.SkipDefaultTransaction = true
+			c.QueryFields = true
+		})
+		require.NoError(t, opt(cfg, &dsnParams{}, &poolParams{}))
+		assert.True(t, cfg.SkipDefaultTransaction)
+		assert.True(t, cfg.QueryFields)
+	})
+
+	t.Run("Runs alongside other options in order", func(t *testing.T) {
+		cfg := &gorm.Config{}
+		opts := []Option{
+			WithPrepareStmt(true),
+			WithGormConfig(func(c *gorm.Config) { c.FullSaveAssociations = true }),
+		}
+		for _, opt := range opts {
+			require.NoError(t, opt(cfg, &dsnParams{}, &poolParams{}))
+		}
+		assert.True(t, cfg.PrepareStmt)
+		assert.True(t, cfg.FullSaveAssociations)
+	})
+
+	t.Run("Nil func should fail", func(t *testing.T) {
+		err := WithGormConfig(nil)(&gorm.Config{}, &dsnParams{}, &poolParams{})
+		assert.Error(t, err)
+	})
+}
+
 func TestConfigurePool(t *testing.T) {
 	t.Run("Configure pool with valid parameters", func(t *testing.T) {
 		params := &poolParams{
@@ -220,6 +301,31 @@ func TestConfigurePool(t *testing.T) {
 	})
 }
 
+func TestWithUnlimitedConns(t *testing.T) {
+	t.Run("Leaves MaxOpenConns at the unlimited default", func(t *testing.T) {
+		pool := &poolParams{}
+		opts := []Option{
+			WithConnectionPool(10, 5, 0, 0),
+			WithUnlimitedConns(),
+		}
+		for _, opt := range opts {
+			require.NoError(t, opt(&gorm.Config{}, &dsnParams{}, pool))
+		}
+		assert.Equal(t, 0, pool.MaxOpenConns)
+		assert.Equal(t, 5, pool.MaxIdleConns)
+
+		_, sqlDB := newFakeDB(t)
+		defer sqlDB.Close()
+		configurePool(sqlDB, pool)
+		assert.Equal(t, 0, sqlDB.Stats().MaxOpenConnections)
+	})
+
+	t.Run("Negative maxOpen still fails", func(t *testing.T) {
+		err := WithConnectionPool(-1, 0, 0, 0)(&gorm.Config{}, &dsnParams{}, &poolParams{})
+		assert.ErrorContains(t, err, "maxOpen cannot be negative")
+	})
+}
+
 func TestHealthCheck(t *testing.T) {
 	t.Run("Health check with valid connection", func(t *testing.T) {
 		// This test requires a real database connection to be meaningful
@@ -232,6 +338,18 @@ func TestHealthCheck(t *testing.T) {
 	})
 }
 
+func TestHealthCheck_CanceledContext(t *testing.T) {
+	db, sqlDB := newFakeDB(t)
+	defer sqlDB.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := HealthCheck(ctx, db)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, sqlDB.Stats().OpenConnections)
+}
+
 func TestClose(t *testing.T) {
 	t.Run("Close with valid connection", func(t *testing.T) {
 		// This test requires a real database connection to be meaningful
@@ -239,6 +357,23 @@ func TestClose(t *testing.T) {
 	})
 }
 
+func TestCloser(t *testing.T) {
+	t.Run("Close delegates to the underlying sql.DB", func(t *testing.T) {
+		db, sqlDB := newFakeDB(t)
+
+		err := Closer(db).Close()
+		assert.NoError(t, err)
+		assert.ErrorContains(t, sqlDB.Ping(), "database is closed")
+	})
+
+	t.Run("Close returns the underlying error", func(t *testing.T) {
+		db := &gorm.DB{Config: &gorm.Config{}}
+
+		err := Closer(db).Close()
+		assert.ErrorIs(t, err, gorm.ErrInvalidDB)
+	})
+}
+
 func TestNewMySQLDB(t *testing.T) {
 	t.Run("NewMySQLDB with valid config", func(t *testing.T) {
 		// This test would require a real MySQL server to connect to
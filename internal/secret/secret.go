@@ -0,0 +1,10 @@
+// Package secret holds helpers for keeping credentials out of diagnostic output.
+package secret
+
+// Mask hides a non-empty secret, leaving an empty one empty so an unset value stays visible.
+func Mask(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "***"
+}
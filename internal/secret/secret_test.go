@@ -0,0 +1,14 @@
+package secret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMask(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "***", Mask("s3cr3t"))
+	assert.Equal(t, "", Mask(""))
+}
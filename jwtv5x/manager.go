@@ -12,6 +12,7 @@ import (
 
 var (
 	ErrInvalidTokenType = errors.New("invalid token type")
+	ErrUnknownKeyID     = errors.New("unknown key id")
 )
 
 // reservedClaims are claim keys that ExtraClaims must not overwrite.
@@ -49,6 +50,36 @@ func WithAudience(audience string) Option {
 	return func(m *Manager) { m.audience = audience }
 }
 
+// WithKeyID sets the kid header on every issued access and refresh token, identifying the signing
+// key for rotation and external verifiers. Once a kid is known (via WithKeyID or WithPreviousKey),
+// tokens carrying any other kid are rejected on parse with ErrUnknownKeyID; tokens without one
+// (issued before the kid was configured) are still verified with the current keys.
+func WithKeyID(kid string) Option {
+	return func(m *Manager) { m.keyID = kid }
+}
+
+// WithPreviousKey registers retired signing keys under kid so tokens issued before a key rotation
+// keep verifying until they expire. New tokens are always signed with the current keys. kid and
+// both keys must be non-empty and kid must differ from the WithKeyID kid, otherwise New fails.
+func WithPreviousKey(kid string, accessTokenKey, refreshTokenKey []byte) Option {
+	return func(m *Manager) {
+		if m.previousKeys == nil {
+			m.previousKeys = make(map[string]keyPair)
+		}
+		m.previousKeys[kid] = keyPair{access: accessTokenKey, refresh: refreshTokenKey}
+	}
+}
+
+// WithRefreshTTLFromAccess derives the refresh TTL as multiplier times the access TTL whenever
+// Generate or Refresh is called with a zero RefreshTTL. The multiplier must be greater than 1,
+// otherwise New fails; an explicit RefreshTTL always takes precedence.
+func WithRefreshTTLFromAccess(multiplier float64) Option {
+	return func(m *Manager) {
+		m.refreshRatio = multiplier
+		m.refreshRatioSet = true
+	}
+}
+
 func WithClock(clock Clock) Option {
 	return func(m *Manager) {
 		if clock != nil {
@@ -64,10 +95,20 @@ type Manager struct {
 	signingMethod   jwt.SigningMethod
 	issuer          string
 	audience        string
+	keyID           string
+	previousKeys    map[string]keyPair
+	refreshRatio    float64
+	refreshRatioSet bool
 	store           RefreshTokenStore
 	clock           Clock
 }
 
+// keyPair holds the access and refresh keys registered under one kid.
+type keyPair struct {
+	access  []byte
+	refresh []byte
+}
+
 // GenerateInput holds parameters for generating a token pair.
 type GenerateInput struct {
 	UserID      string
@@ -114,6 +155,17 @@ func New(accessTokenKey, refreshTokenKey []byte, store RefreshTokenStore, opts .
 	for _, opt := range opts {
 		opt(m)
 	}
+	if m.refreshRatioSet && !(m.refreshRatio > 1) {
+		return nil, fmt.Errorf("refresh TTL multiplier must be > 1")
+	}
+	for kid, keys := range m.previousKeys {
+		if kid == "" || kid == m.keyID {
+			return nil, fmt.Errorf("previous key id %q must be non-empty and differ from the current key id", kid)
+		}
+		if len(keys.access) == 0 || len(keys.refresh) == 0 {
+			return nil, fmt.Errorf("previous keys for key id %q must not be empty", kid)
+		}
+	}
 	return m, nil
 }
 
@@ -125,6 +177,7 @@ func (m *Manager) Generate(ctx context.Context, in GenerateInput) (*TokenPair, e
 	if in.AccessTTL <= 0 {
 		return nil, fmt.Errorf("accessTTL must be > 0")
 	}
+	in.RefreshTTL = m.refreshTTL(in.AccessTTL, in.RefreshTTL)
 	if in.RefreshTTL <= 0 {
 		return nil, fmt.Errorf("refreshTTL must be > 0")
 	}
@@ -153,7 +206,7 @@ func (m *Manager) Generate(ctx context.Context, in GenerateInput) (*TokenPair, e
 		}
 	}
 
-	accessToken, err := jwt.NewWithClaims(m.signingMethod, accessClaims).SignedString(m.accessTokenKey)
+	accessToken, err := m.newToken(accessClaims).SignedString(m.accessTokenKey)
 	if err != nil {
 		return nil, fmt.Errorf("sign access token: %w", err)
 	}
@@ -177,7 +230,7 @@ func (m *Manager) Generate(ctx context.Context, in GenerateInput) (*TokenPair, e
 		refreshTokenClaims["aud"] = []string{m.audience}
 	}
 
-	refreshToken, err := jwt.NewWithClaims(m.signingMethod, refreshTokenClaims).SignedString(m.refreshTokenKey)
+	refreshToken, err := m.newToken(refreshTokenClaims).SignedString(m.refreshTokenKey)
 	if err != nil {
 		return nil, fmt.Errorf("sign refresh token: %w", err)
 	}
@@ -192,14 +245,51 @@ func (m *Manager) Generate(ctx context.Context, in GenerateInput) (*TokenPair, e
 	}, nil
 }
 
+// refreshTTL returns refreshTTL, or the TTL derived from accessTTL when it is zero and a
+// multiplier is configured.
+func (m *Manager) refreshTTL(accessTTL, refreshTTL time.Duration) time.Duration {
+	if refreshTTL != 0 || m.refreshRatio == 0 {
+		return refreshTTL
+	}
+	return time.Duration(float64(accessTTL) * m.refreshRatio)
+}
+
+// newToken creates an unsigned token with the configured signing method and kid header.
+func (m *Manager) newToken(claims jwt.MapClaims) *jwt.Token {
+	token := jwt.NewWithClaims(m.signingMethod, claims)
+	if m.keyID != "" {
+		token.Header["kid"] = m.keyID
+	}
+	return token
+}
+
+// verificationKey selects the key of type typ for token by its kid header: the current key for the
+// current kid or a token without one, a previous key for a kid registered with WithPreviousKey.
+// Without any configured kid the header is not checked.
+func (m *Manager) verificationKey(token *jwt.Token, typ TokenType) (any, error) {
+	if token.Method != m.signingMethod {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	keys := keyPair{access: m.accessTokenKey, refresh: m.refreshTokenKey}
+	if kid, ok := token.Header["kid"]; ok && (m.keyID != "" || len(m.previousKeys) > 0) {
+		s, _ := kid.(string)
+		if previous, found := m.previousKeys[s]; found {
+			keys = previous
+		} else if s == "" || s != m.keyID {
+			return nil, fmt.Errorf("%w: %v", ErrUnknownKeyID, kid)
+		}
+	}
+	if typ == TokenTypeRefresh {
+		return keys.refresh, nil
+	}
+	return keys.access, nil
+}
+
 // ParseAccessToken validates an access token string and returns its claims.
 func (m *Manager) ParseAccessToken(tokenString string) (jwt.MapClaims, error) {
 	claims := jwt.MapClaims{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
-		if token.Method != m.signingMethod {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return m.accessTokenKey, nil
+		return m.verificationKey(token, TokenTypeAccess)
 	}, jwt.WithTimeFunc(m.clock.Now))
 	if err != nil {
 		return nil, err
@@ -224,11 +314,8 @@ type refreshClaims struct {
 func (m *Manager) parseRefreshToken(tokenString string) (*refreshClaims, error) {
 	claims := &refreshClaims{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
-		if token.Method != m.signingMethod {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return m.refreshTokenKey, nil
-	}, jwt.WithTimeFunc(m.clock.Now))
+		return m.verificationKey(token, TokenTypeRefresh)
+	}, jwt.WithTimeFunc(m.clock.Now), jwt.WithValidMethods([]string{m.signingMethod.Alg()}))
 	if err != nil {
 		return nil, err
 	}
@@ -242,10 +329,12 @@ func (m *Manager) parseRefreshToken(tokenString string) (*refreshClaims, error)
 }
 
 // Refresh consumes the old refresh token (one-time use) and generates a new token pair.
+// Rejected tokens wrap ErrRefreshTokenNotFound or ErrRefreshTokenUsed; store failures wrap ErrRefreshTokenStore.
 func (m *Manager) Refresh(ctx context.Context, in RefreshInput) (*TokenPair, error) {
 	if in.AccessTTL <= 0 {
 		return nil, fmt.Errorf("accessTTL must be > 0")
 	}
+	in.RefreshTTL = m.refreshTTL(in.AccessTTL, in.RefreshTTL)
 	if in.RefreshTTL <= 0 {
 		return nil, fmt.Errorf("refreshTTL must be > 0")
 	}
@@ -262,7 +351,10 @@ func (m *Manager) Refresh(ctx context.Context, in RefreshInput) (*TokenPair, err
 	}
 
 	if err := m.store.Consume(ctx, old.Subject, old.ID); err != nil {
-		return nil, fmt.Errorf("consume refresh token: %w", err)
+		if errors.Is(err, ErrRefreshTokenNotFound) || errors.Is(err, ErrRefreshTokenUsed) {
+			return nil, fmt.Errorf("consume refresh token: %w", err)
+		}
+		return nil, fmt.Errorf("consume refresh token: %w: %w", ErrRefreshTokenStore, err)
 	}
 
 	return m.Generate(ctx, GenerateInput{
@@ -51,7 +51,7 @@ func (s *mockStore) Consume(ctx context.Context, userID, tokenID string) error {
 	if s.consumeFunc != nil {
 		return s.consumeFunc(ctx, userID, tokenID)
 	}
-	if s.consumedTokens[tokenID] {
+	if s.consumedTokens[tokenID] || s.revokedUsers[userID] {
 		return ErrRefreshTokenUsed
 	}
 	if saved, ok := s.savedTokens[userID]; !ok || saved != tokenID {
@@ -368,6 +368,232 @@ func TestParseAccessToken(t *testing.T) {
 	})
 }
 
+// ---------------------------------------------------------------------------
+// WithKeyID
+// ---------------------------------------------------------------------------
+
+func TestWithKeyID(t *testing.T) {
+	t.Parallel()
+
+	headerKID := func(t *testing.T, tokenString string) any {
+		t.Helper()
+		token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+		require.NoError(t, err)
+		return token.Header["kid"]
+	}
+
+	t.Run("kid header on generated and refreshed tokens", func(t *testing.T) {
+		t.Parallel()
+		m := newTestManager(t, newMockStore(), WithKeyID("key-2025"))
+		pair, err := m.Generate(context.Background(), defaultInput())
+		require.NoError(t, err)
+		assert.Equal(t, "key-2025", headerKID(t, pair.AccessToken))
+		assert.Equal(t, "key-2025", headerKID(t, pair.RefreshToken))
+
+		refreshed, err := m.Refresh(context.Background(), RefreshInput{
+			RefreshToken: pair.RefreshToken,
+			AccessTTL:    15 * time.Minute,
+			RefreshTTL:   time.Hour,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "key-2025", headerKID(t, refreshed.AccessToken))
+		assert.Equal(t, "key-2025", headerKID(t, refreshed.RefreshToken))
+	})
+
+	t.Run("no kid header by default", func(t *testing.T) {
+		t.Parallel()
+		m := newTestManager(t, newMockStore())
+		pair, err := m.Generate(context.Background(), defaultInput())
+		require.NoError(t, err)
+		assert.Nil(t, headerKID(t, pair.AccessToken))
+	})
+
+	t.Run("kid selects the verification key", func(t *testing.T) {
+		t.Parallel()
+		issuer := newTestManager(t, newMockStore(), WithKeyID("key-old"))
+		pair, err := issuer.Generate(context.Background(), defaultInput())
+		require.NoError(t, err)
+
+		// Same key material, but the manager only knows kid "key-new".
+		verifier := newTestManager(t, newMockStore(), WithKeyID("key-new"))
+		_, err = verifier.ParseAccessToken(pair.AccessToken)
+		assert.ErrorIs(t, err, ErrUnknownKeyID)
+
+		_, err = issuer.ParseAccessToken(pair.AccessToken)
+		assert.NoError(t, err)
+	})
+
+	t.Run("token without kid still accepted", func(t *testing.T) {
+		t.Parallel()
+		pair, err := newTestManager(t, newMockStore()).Generate(context.Background(), defaultInput())
+		require.NoError(t, err)
+
+		_, err = newTestManager(t, newMockStore(), WithKeyID("key-2025")).ParseAccessToken(pair.AccessToken)
+		assert.NoError(t, err)
+	})
+
+	t.Run("kid not checked when none configured", func(t *testing.T) {
+		t.Parallel()
+		pair, err := newTestManager(t, newMockStore(), WithKeyID("key-2025")).Generate(context.Background(), defaultInput())
+		require.NoError(t, err)
+
+		_, err = newTestManager(t, newMockStore()).ParseAccessToken(pair.AccessToken)
+		assert.NoError(t, err)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// WithPreviousKey
+// ---------------------------------------------------------------------------
+
+func TestWithPreviousKey(t *testing.T) {
+	t.Parallel()
+
+	newAccessKey := []byte("rotated-access-key-at-least-32-bytes")
+	newRefreshKey := []byte("rotated-refresh-key-at-least-32-bytes")
+
+	newRotated := func(t *testing.T, store *mockStore) *Manager {
+		t.Helper()
+		m, err := New(newAccessKey, newRefreshKey, store,
+			WithClock(&mockClock{now: testNow}),
+			WithKeyID("key-new"),
+			WithPreviousKey("key-old", testAccessKey, testRefreshKey))
+		require.NoError(t, err)
+		return m
+	}
+
+	t.Run("tokens signed with a previous key still verify", func(t *testing.T) {
+		t.Parallel()
+		store := newMockStore()
+		old := newTestManager(t, store, WithKeyID("key-old"))
+		pair, err := old.Generate(context.Background(), defaultInput())
+		require.NoError(t, err)
+
+		rotated := newRotated(t, store)
+		_, err = rotated.ParseAccessToken(pair.AccessToken)
+		require.NoError(t, err)
+
+		refreshed, err := rotated.Refresh(context.Background(), RefreshInput{
+			RefreshToken: pair.RefreshToken,
+			AccessTTL:    15 * time.Minute,
+			RefreshTTL:   time.Hour,
+		})
+		require.NoError(t, err)
+
+		// Refreshed tokens are signed with the current key, which the old manager cannot verify.
+		_, err = rotated.ParseAccessToken(refreshed.AccessToken)
+		assert.NoError(t, err)
+		_, err = old.ParseAccessToken(refreshed.AccessToken)
+		assert.ErrorIs(t, err, ErrUnknownKeyID)
+	})
+
+	t.Run("previous key is not tried for other kids", func(t *testing.T) {
+		t.Parallel()
+		pair, err := newTestManager(t, newMockStore(), WithKeyID("key-other")).Generate(context.Background(), defaultInput())
+		require.NoError(t, err)
+
+		_, err = newRotated(t, newMockStore()).ParseAccessToken(pair.AccessToken)
+		assert.ErrorIs(t, err, ErrUnknownKeyID)
+	})
+
+	t.Run("invalid previous keys", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			opts []Option
+		}{
+			{"empty kid", []Option{WithPreviousKey("", testAccessKey, testRefreshKey)}},
+			{"same as current kid", []Option{WithKeyID("k"), WithPreviousKey("k", testAccessKey, testRefreshKey)}},
+			{"empty access key", []Option{WithPreviousKey("old", nil, testRefreshKey)}},
+			{"empty refresh key", []Option{WithPreviousKey("old", testAccessKey, nil)}},
+		}
+		for _, tt := range tests {
+			_, err := New(newAccessKey, newRefreshKey, newMockStore(), tt.opts...)
+			assert.Error(t, err, tt.name)
+		}
+	})
+}
+
+// ---------------------------------------------------------------------------
+// WithRefreshTTLFromAccess
+// ---------------------------------------------------------------------------
+
+func TestWithRefreshTTLFromAccess(t *testing.T) {
+	t.Parallel()
+
+	refreshLifetime := func(t *testing.T, tokenString string) time.Duration {
+		t.Helper()
+		claims := jwt.MapClaims{}
+		_, _, err := jwt.NewParser().ParseUnverified(tokenString, claims)
+		require.NoError(t, err)
+		exp, err := claims.GetExpirationTime()
+		require.NoError(t, err)
+		iat, err := claims.GetIssuedAt()
+		require.NoError(t, err)
+		return exp.Sub(iat.Time)
+	}
+
+	t.Run("derived when refresh TTL is zero", func(t *testing.T) {
+		t.Parallel()
+		store := newMockStore()
+		var savedExp time.Time
+		store.saveFunc = func(_ context.Context, _, _ string, expiresAt time.Time) error {
+			savedExp = expiresAt
+			return nil
+		}
+		m := newTestManager(t, store, WithRefreshTTLFromAccess(2.5))
+
+		in := defaultInput()
+		in.RefreshTTL = 0
+		pair, err := m.Generate(context.Background(), in)
+		require.NoError(t, err)
+
+		want := time.Duration(float64(in.AccessTTL) * 2.5)
+		assert.Equal(t, want, refreshLifetime(t, pair.RefreshToken))
+		assert.Equal(t, testNow.Add(want), savedExp)
+	})
+
+	t.Run("derived on refresh", func(t *testing.T) {
+		t.Parallel()
+		m := newTestManager(t, newMockStore(), WithRefreshTTLFromAccess(4))
+		pair, err := m.Generate(context.Background(), defaultInput())
+		require.NoError(t, err)
+
+		refreshed, err := m.Refresh(context.Background(), RefreshInput{
+			RefreshToken: pair.RefreshToken,
+			AccessTTL:    10 * time.Minute,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 40*time.Minute, refreshLifetime(t, refreshed.RefreshToken))
+	})
+
+	t.Run("explicit refresh TTL wins", func(t *testing.T) {
+		t.Parallel()
+		m := newTestManager(t, newMockStore(), WithRefreshTTLFromAccess(2))
+		in := defaultInput()
+		pair, err := m.Generate(context.Background(), in)
+		require.NoError(t, err)
+		assert.Equal(t, in.RefreshTTL, refreshLifetime(t, pair.RefreshToken))
+	})
+
+	t.Run("zero refresh TTL still rejected without multiplier", func(t *testing.T) {
+		t.Parallel()
+		m := newTestManager(t, newMockStore())
+		in := defaultInput()
+		in.RefreshTTL = 0
+		_, err := m.Generate(context.Background(), in)
+		assert.ErrorContains(t, err, "refreshTTL must be > 0")
+	})
+
+	t.Run("multiplier must exceed 1", func(t *testing.T) {
+		t.Parallel()
+		for _, multiplier := range []float64{1, 0.5, 0, -2} {
+			_, err := New(testAccessKey, testRefreshKey, newMockStore(), WithRefreshTTLFromAccess(multiplier))
+			assert.ErrorContains(t, err, "refresh TTL multiplier must be > 1", "multiplier %v", multiplier)
+		}
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Refresh
 // ---------------------------------------------------------------------------
@@ -501,6 +727,83 @@ func TestRefresh(t *testing.T) {
 		})
 		assert.ErrorContains(t, err, "consume refresh token")
 		assert.ErrorContains(t, err, "redis timeout")
+		assert.ErrorIs(t, err, ErrRefreshTokenStore)
+	})
+
+	t.Run("unknown token distinguishable from store failure", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		in := RefreshInput{
+			AccessTTL:  15 * time.Minute,
+			RefreshTTL: 7 * 24 * time.Hour,
+		}
+
+		// Token signed correctly but its JTI was never saved by this store.
+		issuer := newTestManager(t, newMockStore())
+		pair, err := issuer.Generate(ctx, defaultInput())
+		require.NoError(t, err)
+		in.RefreshToken = pair.RefreshToken
+
+		notFound := newTestManager(t, newMockStore())
+		_, notFoundErr := notFound.Refresh(ctx, in)
+		assert.ErrorIs(t, notFoundErr, ErrRefreshTokenNotFound)
+		assert.NotErrorIs(t, notFoundErr, ErrRefreshTokenStore)
+
+		failing := newMockStore()
+		failing.consumeFunc = func(context.Context, string, string) error {
+			return fmt.Errorf("connection refused")
+		}
+		_, storeErr := newTestManager(t, failing).Refresh(ctx, in)
+		assert.ErrorIs(t, storeErr, ErrRefreshTokenStore)
+		assert.NotErrorIs(t, storeErr, ErrRefreshTokenNotFound)
+	})
+
+	t.Run("revoked token reported as used", func(t *testing.T) {
+		t.Parallel()
+		store := newMockStore()
+		m := newTestManager(t, store)
+		ctx := context.Background()
+
+		pair, err := m.Generate(ctx, defaultInput())
+		require.NoError(t, err)
+		require.NoError(t, m.RevokeUserRefreshTokens(ctx, "user-123"))
+
+		_, err = m.Refresh(ctx, RefreshInput{
+			RefreshToken: pair.RefreshToken,
+			AccessTTL:    15 * time.Minute,
+			RefreshTTL:   7 * 24 * time.Hour,
+		})
+		assert.ErrorIs(t, err, ErrRefreshTokenUsed)
+		assert.NotErrorIs(t, err, ErrRefreshTokenStore)
+	})
+
+	t.Run("alg none rejected before consume", func(t *testing.T) {
+		t.Parallel()
+		store := newMockStore()
+		consumed := false
+		store.consumeFunc = func(context.Context, string, string) error {
+			consumed = true
+			return nil
+		}
+		m := newTestManager(t, store)
+
+		forged, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
+			"sub": "user-123",
+			"jti": "forged-jti",
+			"iat": testNow.Unix(),
+			"nbf": testNow.Unix(),
+			"exp": testNow.Add(time.Hour).Unix(),
+			"typ": string(TokenTypeRefresh),
+		}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = m.Refresh(context.Background(), RefreshInput{
+			RefreshToken: forged,
+			AccessTTL:    15 * time.Minute,
+			RefreshTTL:   7 * 24 * time.Hour,
+		})
+		assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+		assert.False(t, consumed)
 	})
 
 	t.Run("refresh with extra claims", func(t *testing.T) {
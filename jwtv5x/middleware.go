@@ -0,0 +1,78 @@
+package jwtv5x
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrMissingBearerToken = errors.New("missing bearer token")
+)
+
+type claimsContextKey struct{}
+
+// AuditFunc receives every token validation decision made by the Middleware.
+// subject is the token's user ID on success and empty on failure; err carries the rejection reason.
+type AuditFunc func(r *http.Request, subject string, err error)
+
+// MiddlewareOption configures the Middleware.
+type MiddlewareOption func(*middlewareConfig)
+
+type middlewareConfig struct {
+	audit AuditFunc
+}
+
+// WithAuditLogger records the outcome of each token validation, including failures
+// such as expired or malformed tokens. Use r.RemoteAddr or forwarding headers for the source IP.
+func WithAuditLogger(audit AuditFunc) MiddlewareOption {
+	return func(c *middlewareConfig) { c.audit = audit }
+}
+
+// Middleware authenticates requests with a Bearer access token.
+// Valid claims are stored in the request context (see ClaimsFromContext);
+// requests without a valid token are rejected with 401 Unauthorized.
+func (m *Manager) Middleware(opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	cfg := &middlewareConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, err := m.authenticate(r)
+
+			if cfg.audit != nil {
+				subject := ""
+				if err == nil {
+					subject, _ = claims["uid"].(string)
+				}
+				cfg.audit(r, subject, err)
+			}
+
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), claimsContextKey{}, claims)))
+		})
+	}
+}
+
+// ClaimsFromContext returns the access token claims stored by the Middleware.
+func ClaimsFromContext(ctx context.Context) (jwt.MapClaims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(jwt.MapClaims)
+	return claims, ok
+}
+
+// authenticate extracts the Bearer token from r and validates it as an access token.
+func (m *Manager) authenticate(r *http.Request) (jwt.MapClaims, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, ErrMissingBearerToken
+	}
+	return m.ParseAccessToken(token)
+}
@@ -0,0 +1,129 @@
+package jwtv5x
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type auditEntry struct {
+	remoteAddr string
+	subject    string
+	err        error
+}
+
+func newAuditedServer(t *testing.T, m *Manager) (http.Handler, *[]auditEntry) {
+	t.Helper()
+	var entries []auditEntry
+	audit := WithAuditLogger(func(r *http.Request, subject string, err error) {
+		entries = append(entries, auditEntry{remoteAddr: r.RemoteAddr, subject: subject, err: err})
+	})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := ClaimsFromContext(r.Context())
+		require.True(t, ok)
+		_, _ = w.Write([]byte(claims["uid"].(string)))
+	})
+	return m.Middleware(audit)(next), &entries
+}
+
+func serveWithToken(h http.Handler, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "203.0.113.7:51234"
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid token audited with subject", func(t *testing.T) {
+		t.Parallel()
+		m := newTestManager(t, newMockStore())
+		pair, err := m.Generate(context.Background(), defaultInput())
+		require.NoError(t, err)
+
+		h, entries := newAuditedServer(t, m)
+		rec := serveWithToken(h, pair.AccessToken)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "user-123", rec.Body.String())
+		require.Len(t, *entries, 1)
+		assert.Equal(t, "user-123", (*entries)[0].subject)
+		assert.NoError(t, (*entries)[0].err)
+		assert.Equal(t, "203.0.113.7:51234", (*entries)[0].remoteAddr)
+	})
+
+	t.Run("expired token audited with reason", func(t *testing.T) {
+		t.Parallel()
+		clock := &mockClock{now: testNow}
+		m := newTestManager(t, newMockStore(), WithClock(clock))
+		pair, err := m.Generate(context.Background(), defaultInput())
+		require.NoError(t, err)
+		clock.Advance(time.Hour)
+
+		h, entries := newAuditedServer(t, m)
+		rec := serveWithToken(h, pair.AccessToken)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		require.Len(t, *entries, 1)
+		assert.Empty(t, (*entries)[0].subject)
+		assert.ErrorIs(t, (*entries)[0].err, jwt.ErrTokenExpired)
+	})
+
+	t.Run("invalid token audited with reason", func(t *testing.T) {
+		t.Parallel()
+		m := newTestManager(t, newMockStore())
+
+		h, entries := newAuditedServer(t, m)
+		rec := serveWithToken(h, "not-a-jwt")
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		require.Len(t, *entries, 1)
+		assert.ErrorIs(t, (*entries)[0].err, jwt.ErrTokenMalformed)
+	})
+
+	t.Run("refresh token rejected as access token", func(t *testing.T) {
+		t.Parallel()
+		m := newTestManager(t, newMockStore())
+		pair, err := m.Generate(context.Background(), defaultInput())
+		require.NoError(t, err)
+
+		h, entries := newAuditedServer(t, m)
+		rec := serveWithToken(h, pair.RefreshToken)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		require.Len(t, *entries, 1)
+		assert.Error(t, (*entries)[0].err)
+	})
+
+	t.Run("missing token audited", func(t *testing.T) {
+		t.Parallel()
+		m := newTestManager(t, newMockStore())
+
+		h, entries := newAuditedServer(t, m)
+		rec := serveWithToken(h, "")
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		require.Len(t, *entries, 1)
+		assert.ErrorIs(t, (*entries)[0].err, ErrMissingBearerToken)
+	})
+
+	t.Run("without audit logger", func(t *testing.T) {
+		t.Parallel()
+		m := newTestManager(t, newMockStore())
+		h := m.Middleware()(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+		rec := serveWithToken(h, "")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
@@ -9,6 +9,8 @@ import (
 var (
 	ErrRefreshTokenNotFound = errors.New("refresh token not found")
 	ErrRefreshTokenUsed     = errors.New("refresh token already used or revoked")
+	// ErrRefreshTokenStore wraps store failures (outages, timeouts) that are not a verdict on the token.
+	ErrRefreshTokenStore = errors.New("refresh token store failure")
 )
 
 // RefreshTokenStore persists refresh token JTIs (not the raw JWT) server-side.
@@ -16,7 +18,9 @@ var (
 type RefreshTokenStore interface {
 	// Save stores a refresh token JTI with its expiration time.
 	Save(ctx context.Context, userID, tokenID string, expiresAt time.Time) error
-	// Consume marks a refresh token as used. Returns ErrRefreshTokenUsed if already consumed.
+	// Consume marks a refresh token as used. Returns ErrRefreshTokenNotFound if the JTI is unknown
+	// or expired, and ErrRefreshTokenUsed if already consumed or revoked (wrapping is allowed).
+	// Any other error is treated as a store failure.
 	Consume(ctx context.Context, userID, tokenID string) error
 	// RevokeUserTokens invalidates all refresh tokens for the given user.
 	RevokeUserTokens(ctx context.Context, userID string) error
@@ -0,0 +1,162 @@
+package lumberjackx
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// megabyte is the unit of lumberjack.Logger.MaxSize.
+const megabyte = 1024 * 1024
+
+// cleanupPattern is a glob-based retention rule attached to a logger by WithCleanupPattern.
+type cleanupPattern struct {
+	glob string
+	keep int
+}
+
+// WithCleanupPattern deletes the oldest files matching glob beyond the newest keep,
+// including files created by other tools such as logrotate. The active log file is never deleted.
+// lumberjack has no rotation hook, so NewLogger runs the cleanup once when the logger is created;
+// use NewCleanupLogger to also run it after every rotation, or call CleanupByPattern yourself
+// (e.g. from a logrotate postrotate script).
+func WithCleanupPattern(glob string, keep int) Option {
+	return func(l *LoggerConfig) error {
+		if glob == "" {
+			return errors.New("cleanup pattern cannot be empty")
+		}
+		if _, err := filepath.Match(glob, ""); err != nil {
+			return fmt.Errorf("invalid cleanup pattern: %w", err)
+		}
+		if keep < 0 {
+			return errors.New("cleanup keep cannot be negative")
+		}
+		l.cleanup = append(l.cleanup, cleanupPattern{glob: glob, keep: keep})
+		return nil
+	}
+}
+
+// CleanupLogger is a lumberjack.Logger that runs its WithCleanupPattern rules again after every
+// rotation, whether triggered by Rotate or by a Write that pushes the file past MaxSize.
+type CleanupLogger struct {
+	*lumberjack.Logger
+
+	cleanup []cleanupPattern
+	mu      sync.Mutex
+	size    int64 // bytes in the active file, -1 until the first write
+}
+
+// NewCleanupLogger creates a CleanupLogger configured like NewLogger.
+func NewCleanupLogger(opts ...Option) (*CleanupLogger, error) {
+	cfg, err := newLoggerConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &CleanupLogger{Logger: cfg.Logger, cleanup: cfg.cleanup, size: -1}, nil
+}
+
+// Write implements io.Writer. When the write made lumberjack rotate, the cleanup rules run before
+// it returns and their error, if any, is returned with the full byte count.
+func (l *CleanupLogger) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rotates := l.willRotate(int64(len(p)))
+	n, err := l.Logger.Write(p)
+	if err != nil {
+		return n, err
+	}
+	if !rotates {
+		l.size += int64(n)
+		return n, nil
+	}
+	l.size = int64(n)
+	return n, runCleanup(l.Filename, l.cleanup)
+}
+
+// Rotate closes the current file, starts a new one and runs the cleanup rules.
+func (l *CleanupLogger) Rotate() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.Logger.Rotate(); err != nil {
+		return err
+	}
+	l.size = 0
+	return runCleanup(l.Filename, l.cleanup)
+}
+
+// willRotate mirrors lumberjack's size check for a write of n bytes.
+func (l *CleanupLogger) willRotate(n int64) bool {
+	limit := int64(l.MaxSize) * megabyte
+	if limit == 0 {
+		limit = defaultMaxSizeMB * megabyte
+	}
+	if l.size >= 0 {
+		return l.size+n > limit
+	}
+	// lumberjack opens the existing file on the first write and rotates it if it is already full.
+	info, err := os.Stat(l.Filename)
+	if err != nil {
+		l.size = 0
+		return false
+	}
+	l.size = info.Size()
+	return l.size+n >= limit
+}
+
+// CleanupByPattern deletes the oldest files (by modification time) matching glob beyond the
+// newest keep. activeFile is excluded from both matching and the keep count.
+func CleanupByPattern(glob string, keep int, activeFile string) error {
+	matches, err := filepath.Glob(glob)
+	if err != nil {
+		return fmt.Errorf("invalid cleanup pattern: %w", err)
+	}
+
+	active, _ := filepath.Abs(activeFile)
+	type candidate struct {
+		path    string
+		modTime int64
+	}
+	candidates := make([]candidate, 0, len(matches))
+	for _, path := range matches {
+		if abs, _ := filepath.Abs(path); abs == active {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		candidates = append(candidates, candidate{path: path, modTime: info.ModTime().UnixNano()})
+	}
+	if len(candidates) <= keep {
+		return nil
+	}
+
+	// Newest first; everything past keep is removed.
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].modTime > candidates[j].modTime
+	})
+	var errs []error
+	for _, c := range candidates[keep:] {
+		if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runCleanup applies rules, sparing the active file.
+func runCleanup(filename string, rules []cleanupPattern) error {
+	for _, p := range rules {
+		if err := CleanupByPattern(p.glob, p.keep, filename); err != nil {
+			return fmt.Errorf("log cleanup failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package lumberjackx
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+// seedFiles creates files in dir with increasing modification times (oldest first).
+func seedFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	base := time.Now().Add(-time.Hour)
+	for i, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(name), 0o600); err != nil {
+			t.Fatalf("seed %s: %v", name, err)
+		}
+		mtime := base.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatalf("chtimes %s: %v", name, err)
+		}
+	}
+}
+
+func remainingFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestWithCleanupPatternKeepsNewest(t *testing.T) {
+	dir := t.TempDir()
+	active := filepath.Join(dir, "app.log")
+	// Oldest first; app.log.4 is the newest rotated file (logrotate naming).
+	seedFiles(t, dir, "app.log.1", "app.log.2", "app.log.3", "app.log.4", "other.txt")
+
+	_, err := NewLogger(
+		WithFilename(active),
+		WithCleanupPattern(filepath.Join(dir, "app.log*"), 2),
+	)
+	if err != nil {
+		t.Fatalf("NewLogger returned error: %v", err)
+	}
+
+	got := remainingFiles(t, dir)
+	want := []string{"app.log", "app.log.3", "app.log.4", "other.txt"}
+	if len(got) != len(want) {
+		t.Fatalf("remaining files = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("remaining files = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestCleanupByPatternNeverDeletesActiveFile(t *testing.T) {
+	dir := t.TempDir()
+	active := filepath.Join(dir, "app.log")
+	// The active file is the oldest match and would otherwise be deleted first.
+	seedFiles(t, dir, "app.log", "app.log.1", "app.log.2")
+
+	if err := CleanupByPattern(filepath.Join(dir, "app.log*"), 0, active); err != nil {
+		t.Fatalf("CleanupByPattern returned error: %v", err)
+	}
+
+	got := remainingFiles(t, dir)
+	if len(got) != 1 || got[0] != "app.log" {
+		t.Fatalf("remaining files = %v, want only the active file", got)
+	}
+}
+
+func TestWithCleanupPatternRejectsInvalidInput(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "app.log")
+
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{"empty pattern", WithCleanupPattern("", 1)},
+		{"malformed pattern", WithCleanupPattern("[", 1)},
+		{"negative keep", WithCleanupPattern(filepath.Join(dir, "*.log"), -1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewLogger(WithFilename(filename), tt.opt); err == nil {
+				t.Fatalf("expected error")
+			}
+		})
+	}
+}
+
+func newTestCleanupLogger(t *testing.T, dir string) *CleanupLogger {
+	t.Helper()
+	// app-old.1.log and app-old.2.log stand in for backups left by an earlier run.
+	seedFiles(t, dir, "app-old.1.log", "app-old.2.log")
+	logger, err := NewCleanupLogger(
+		WithFilename(filepath.Join(dir, "app.log")),
+		WithMaxSize(1),
+		WithMaxBackups(0),
+		WithCompress(false),
+		WithCleanupPattern(filepath.Join(dir, "app-*.log"), 1),
+	)
+	if err != nil {
+		t.Fatalf("NewCleanupLogger returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = logger.Close() })
+	return logger
+}
+
+func TestCleanupLoggerRotateRunsCleanup(t *testing.T) {
+	dir := t.TempDir()
+	logger := newTestCleanupLogger(t, dir)
+	if got := remainingFiles(t, dir); len(got) != 2 || got[1] != "app.log" {
+		t.Fatalf("remaining files after create = %v, want one old backup and app.log", got)
+	}
+
+	if _, err := logger.Write([]byte("before rotation\n")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate returned error: %v", err)
+	}
+
+	// The rotated file is the newest backup, so it replaces the old one.
+	got := remainingFiles(t, dir)
+	if len(got) != 2 || got[0] == "app-old.2.log" || got[1] != "app.log" {
+		t.Fatalf("remaining files after rotate = %v, want the new backup and app.log", got)
+	}
+}
+
+func TestCleanupLoggerWriteRotationRunsCleanup(t *testing.T) {
+	dir := t.TempDir()
+	logger := newTestCleanupLogger(t, dir)
+
+	chunk := make([]byte, 600*1024)
+	for i := 0; i < 2; i++ {
+		if _, err := logger.Write(chunk); err != nil {
+			t.Fatalf("Write %d returned error: %v", i, err)
+		}
+	}
+
+	// The second write exceeds MaxSize, so lumberjack rotated and the old backup was cleaned up.
+	got := remainingFiles(t, dir)
+	if len(got) != 2 || got[0] == "app-old.2.log" || got[1] != "app.log" {
+		t.Fatalf("remaining files after size rotation = %v, want the new backup and app.log", got)
+	}
+	info, err := os.Stat(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatalf("stat active file: %v", err)
+	}
+	if info.Size() != int64(len(chunk)) {
+		t.Fatalf("active file size = %d, want %d", info.Size(), len(chunk))
+	}
+}
@@ -18,18 +18,31 @@ const (
 )
 
 // Option defines the function signature for configuration options.
-type Option func(*lumberjack.Logger) error
+type Option func(*LoggerConfig) error
+
+// LoggerConfig is the value Option values configure. It embeds the lumberjack.Logger being built,
+// so a custom option can set any of its fields directly; settings lumberjack has no field for,
+// such as WithCleanupPattern rules, are unexported.
+type LoggerConfig struct {
+	*lumberjack.Logger
+
+	cleanup []cleanupPattern
+}
 
 // WithFilename sets the log file path.
+// The path must not be a directory and must be writable; both are checked eagerly.
 // Default: <processname>-lumberjack.log in os.TempDir().
 func WithFilename(filename string) Option {
-	return func(l *lumberjack.Logger) error {
+	return func(l *LoggerConfig) error {
 		if filename == "" {
 			return errors.New("filename cannot be empty")
 		}
 		if err := ensureLogDir(filename); err != nil {
 			return err
 		}
+		if err := ensureWritable(filename); err != nil {
+			return err
+		}
 		l.Filename = filename
 		return nil
 	}
@@ -38,7 +51,7 @@ func WithFilename(filename string) Option {
 // WithMaxSize sets the maximum size of a single log file (MB).
 // Default: 100 MB.
 func WithMaxSize(sizeMB int) Option {
-	return func(l *lumberjack.Logger) error {
+	return func(l *LoggerConfig) error {
 		if sizeMB <= 0 {
 			return errors.New("maxsize must be positive")
 		}
@@ -50,7 +63,7 @@ func WithMaxSize(sizeMB int) Option {
 // WithMaxAge sets the maximum retention days for log files.
 // Default: 7 days.
 func WithMaxAge(days int) Option {
-	return func(l *lumberjack.Logger) error {
+	return func(l *LoggerConfig) error {
 		if days < 0 {
 			return errors.New("maxage cannot be negative")
 		}
@@ -62,7 +75,7 @@ func WithMaxAge(days int) Option {
 // WithMaxBackups sets the maximum number of backup files.
 // Default: 7 backups.
 func WithMaxBackups(count int) Option {
-	return func(l *lumberjack.Logger) error {
+	return func(l *LoggerConfig) error {
 		if count < 0 {
 			return errors.New("maxbackups cannot be negative")
 		}
@@ -74,7 +87,7 @@ func WithMaxBackups(count int) Option {
 // WithLocalTime sets whether to use local time for backup file naming.
 // Default: true.
 func WithLocalTime(useLocal bool) Option {
-	return func(l *lumberjack.Logger) error {
+	return func(l *LoggerConfig) error {
 		l.LocalTime = useLocal
 		return nil
 	}
@@ -83,7 +96,7 @@ func WithLocalTime(useLocal bool) Option {
 // WithCompress sets whether to compress old log files.
 // Default: true.
 func WithCompress(compress bool) Option {
-	return func(l *lumberjack.Logger) error {
+	return func(l *LoggerConfig) error {
 		l.Compress = compress
 		return nil
 	}
@@ -92,30 +105,45 @@ func WithCompress(compress bool) Option {
 // NewLogger creates and configures a lumberjack.Logger instance.
 // All parameters are optional; defaults are used when not specified.
 func NewLogger(opts ...Option) (*lumberjack.Logger, error) {
+	cfg, err := newLoggerConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Logger, nil
+}
+
+// newLoggerConfig applies opts over the defaults, prepares the log directory and runs the
+// cleanup rules once.
+func newLoggerConfig(opts ...Option) (*LoggerConfig, error) {
 	filename := defaultFilename()
 
 	// Create instance and explicitly set default values.
-	logger := &lumberjack.Logger{
-		Filename:   filename,
-		MaxSize:    defaultMaxSizeMB,
-		MaxAge:     defaultMaxAgeDays,
-		MaxBackups: defaultMaxBackups,
-		LocalTime:  true,
-		Compress:   true,
+	cfg := &LoggerConfig{
+		Logger: &lumberjack.Logger{
+			Filename:   filename,
+			MaxSize:    defaultMaxSizeMB,
+			MaxAge:     defaultMaxAgeDays,
+			MaxBackups: defaultMaxBackups,
+			LocalTime:  true,
+			Compress:   true,
+		},
 	}
 
 	// Apply all options.
 	for _, opt := range opts {
-		if err := opt(logger); err != nil {
+		if err := opt(cfg); err != nil {
 			return nil, fmt.Errorf("apply option failed: %w", err)
 		}
 	}
 
-	if err := ensureLogDir(logger.Filename); err != nil {
+	if err := ensureLogDir(cfg.Filename); err != nil {
+		return nil, err
+	}
+	if err := runCleanup(cfg.Filename, cfg.cleanup); err != nil {
 		return nil, err
 	}
 
-	return logger, nil
+	return cfg, nil
 }
 
 // MustNewLogger creates a Logger and panics on error (suitable for startup phase).
@@ -138,6 +166,19 @@ func ensureLogDir(filename string) error {
 	return nil
 }
 
+// ensureWritable rejects directories and verifies the log file can be opened for appending.
+// A missing file is created with the same permissions lumberjack uses for new files.
+func ensureWritable(filename string) error {
+	if info, err := os.Stat(filename); err == nil && info.IsDir() {
+		return fmt.Errorf("log file path is a directory: %s", filename)
+	}
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("log file is not writable: %w", err)
+	}
+	return f.Close()
+}
+
 func defaultFilename() string {
 	return filepath.Join(os.TempDir(), fmt.Sprintf(defaultFilenameFmt, defaultProcessName()))
 }
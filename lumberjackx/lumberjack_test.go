@@ -3,6 +3,7 @@ package lumberjackx
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -80,3 +81,50 @@ func TestNewLoggerWithOptions(t *testing.T) {
 		t.Fatalf("expected custom log directory to be created: %v", err)
 	}
 }
+
+func TestWithFilenameRejectsDirectory(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := NewLogger(WithFilename(dir)); err == nil {
+		t.Fatalf("expected error for directory path %q", dir)
+	} else if !strings.Contains(err.Error(), "is a directory") {
+		t.Fatalf("unexpected error for directory path: %v", err)
+	}
+}
+
+func TestWithFilenameRejectsUnwritable(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root bypasses file permission checks")
+	}
+
+	dir := filepath.Join(t.TempDir(), "readonly")
+	if err := os.Mkdir(dir, 0o500); err != nil {
+		t.Fatalf("create read-only dir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chmod(dir, 0o700) })
+
+	if _, err := NewLogger(WithFilename(filepath.Join(dir, "app.log"))); err == nil {
+		t.Fatalf("expected error for unwritable location")
+	} else if !strings.Contains(err.Error(), "not writable") {
+		t.Fatalf("unexpected error for unwritable location: %v", err)
+	}
+}
+
+func TestWithFilenameKeepsExistingContent(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(filename, []byte("existing\n"), 0o600); err != nil {
+		t.Fatalf("seed log file: %v", err)
+	}
+
+	if _, err := NewLogger(WithFilename(filename)); err != nil {
+		t.Fatalf("NewLogger with existing file returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("read log file: %v", err)
+	}
+	if string(data) != "existing\n" {
+		t.Fatalf("existing log content changed: %q", data)
+	}
+}
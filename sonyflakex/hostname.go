@@ -0,0 +1,63 @@
+package sonyflakex
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// hostname is swapped out in tests
+var hostname = os.Hostname
+
+// ordinalRepo hands out a fixed machine ID; there is no lease to renew or release
+type ordinalRepo struct {
+	machineID int
+}
+
+// HostnameOrdinalRepo returns a Repo whose machine ID is the trailing integer of the hostname
+// (e.g. StatefulSet pod "app-3" → 3). The ID is static: renew and release are no-ops,
+// so uniqueness relies on the orchestrator never running two pods with the same ordinal
+func HostnameOrdinalRepo(maxID int) (Repo, error) {
+	name, err := hostname()
+	if err != nil {
+		return nil, fmt.Errorf("get hostname: %w", err)
+	}
+
+	machineID, err := parseOrdinal(name)
+	if err != nil {
+		return nil, err
+	}
+	if machineID > maxID {
+		return nil, fmt.Errorf("hostname ordinal %d exceeds max machine ID %d", machineID, maxID)
+	}
+
+	return &ordinalRepo{machineID: machineID}, nil
+}
+
+// parseOrdinal extracts the trailing decimal integer from name
+func parseOrdinal(name string) (int, error) {
+	digits := name[len(strings.TrimRight(name, "0123456789")):]
+	if digits == "" {
+		return 0, fmt.Errorf("hostname %q has no trailing ordinal", name)
+	}
+	ordinal, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, fmt.Errorf("parse hostname ordinal %q: %w", digits, err)
+	}
+	return ordinal, nil
+}
+
+func (r *ordinalRepo) AcquireMachineID(context.Context, time.Duration) (int, error) {
+	return r.machineID, nil
+}
+
+func (r *ordinalRepo) RenewMachineID(context.Context, int, time.Duration) error {
+	return nil
+}
+
+func (r *ordinalRepo) ReleaseMachineID(context.Context, int) error {
+	return nil
+}
@@ -0,0 +1,96 @@
+package sonyflakex
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// stubHostname replaces the hostname lookup for the duration of the test
+func stubHostname(t *testing.T, name string, err error) {
+	t.Helper()
+	orig := hostname
+	hostname = func() (string, error) { return name, err }
+	t.Cleanup(func() { hostname = orig })
+}
+
+func TestHostnameOrdinalRepo(t *testing.T) {
+	tests := []struct {
+		host string
+		want int
+	}{
+		{"app-3", 3},
+		{"app-0", 0},
+		{"orders-worker-12", 12},
+		{"node7", 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			stubHostname(t, tt.host, nil)
+
+			repo, err := HostnameOrdinalRepo(255)
+			if err != nil {
+				t.Fatalf("HostnameOrdinalRepo() error = %v", err)
+			}
+			id, err := repo.AcquireMachineID(context.Background(), 0)
+			if err != nil {
+				t.Fatalf("AcquireMachineID() error = %v", err)
+			}
+			if id != tt.want {
+				t.Errorf("machine ID = %d, want %d", id, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostnameOrdinalRepo_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    string
+		hostErr error
+		wantMsg string
+	}{
+		{"out of range", "app-256", nil, "exceeds max machine ID"},
+		{"no ordinal", "app", nil, "no trailing ordinal"},
+		{"empty hostname", "", nil, "no trailing ordinal"},
+		{"lookup failure", "", errors.New("boom"), "get hostname"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubHostname(t, tt.host, tt.hostErr)
+
+			repo, err := HostnameOrdinalRepo(255)
+			if err == nil {
+				t.Fatalf("expected error, got repo %v", repo)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestHostnameOrdinalRepo_Generator(t *testing.T) {
+	stubHostname(t, "app-3", nil)
+
+	repo, err := HostnameOrdinalRepo(255)
+	if err != nil {
+		t.Fatalf("HostnameOrdinalRepo() error = %v", err)
+	}
+	gen, err := New(repo)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer gen.Stop(context.Background())
+
+	id, err := gen.NextID()
+	if err != nil {
+		t.Fatalf("NextID() error = %v", err)
+	}
+	if got := gen.Decompose(id)["machine"]; got != 3 {
+		t.Errorf("decomposed machine ID = %d, want 3", got)
+	}
+}
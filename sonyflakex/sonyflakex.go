@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sony/sonyflake/v2"
@@ -16,11 +19,14 @@ var (
 	ErrAcquireMachineID = errors.New("failed to acquire machine ID")
 	ErrReleaseMachineID = errors.New("failed to release machine ID")
 	ErrInvalidBitLength = errors.New("invalid bit length configuration")
+	ErrLifespanTooShort = errors.New("configuration cannot represent the required lifespan")
+	ErrGeneratorStopped = errors.New("generator has been stopped")
 )
 
 const (
 	defaultBitsMachine  = 16
 	defaultBitsSequence = 8
+	defaultBitsTime     = 63 - defaultBitsMachine - defaultBitsSequence
 )
 
 // Repo defines the interface for managing distributed machine IDs
@@ -30,6 +36,12 @@ type Repo interface {
 	ReleaseMachineID(ctx context.Context, machineID int) error
 }
 
+// MetadataRepo is optionally implemented by a Repo that can tag leased machine IDs
+// with instance metadata (e.g. pod name and namespace) for debuggability
+type MetadataRepo interface {
+	AcquireMachineIDWithMeta(ctx context.Context, ttl time.Duration, meta map[string]string) (int, error)
+}
+
 // Generator wraps sonyflake.Sonyflake with distributed machine ID management
 type Generator struct {
 	sf        *sonyflake.Sonyflake
@@ -38,18 +50,26 @@ type Generator struct {
 	stopChan  chan struct{}
 	doneChan  chan struct{}
 	stopOnce  sync.Once
+	stopped   atomic.Bool
 	stopErr   error
 	ttl       time.Duration
 	renewFreq time.Duration
+	jitter    time.Duration
+	timeUnit  time.Duration
 }
 
 // Option defines optional configuration for Generator
 type Option func(*generatorConfig) error
 
 type generatorConfig struct {
-	settings  sonyflake.Settings
-	ttl       time.Duration
-	renewFreq time.Duration
+	settings         sonyflake.Settings
+	ttl              time.Duration
+	renewFreq        time.Duration
+	renewJitter      time.Duration
+	minLifespanYears int
+	meta             map[string]string
+	startTimeSkew    time.Duration
+	now              func() time.Time // clock for validation, replaced in tests
 }
 
 // Default production settings based on best practices:
@@ -65,21 +85,32 @@ func defaultGeneratorConfig() *generatorConfig {
 		},
 		ttl:       30 * time.Second,
 		renewFreq: 10 * time.Second,
+		now:       time.Now,
 	}
 }
 
 // WithStartTime sets the epoch start time
-// Must be in the past to avoid time overflow
+// Must be in the past to avoid time overflow, up to the tolerance set by WithStartTimeSkew
 func WithStartTime(t time.Time) Option {
 	return func(c *generatorConfig) error {
-		if t.After(time.Now()) {
-			return ErrInvalidStartTime
-		}
 		c.settings.StartTime = t
 		return nil
 	}
 }
 
+// WithStartTimeSkew tolerates a start time up to d ahead of the local clock
+// Absorbs clock skew on hosts running slightly behind; New waits until the start time has passed,
+// use NewContext to bound that wait
+func WithStartTimeSkew(d time.Duration) Option {
+	return func(c *generatorConfig) error {
+		if d < 0 {
+			return errors.New("start time skew cannot be negative")
+		}
+		c.startTimeSkew = d
+		return nil
+	}
+}
+
 // WithTimeUnit sets the time unit precision
 // Smaller units provide more precision but reduce lifespan
 // Recommended: 10ms (default) for most cases
@@ -93,6 +124,30 @@ func WithTimeUnit(d time.Duration) Option {
 	}
 }
 
+// StartTimeForLifespan returns a start time for WithStartTime that keeps the time bits
+// from overflowing for at least desiredYears (start of the current UTC year or day)
+// Returns the zero time if the unit cannot cover desiredYears at all
+// Compute once and pin the result: changing the epoch of a live ID space breaks uniqueness
+func StartTimeForLifespan(timeUnit time.Duration, desiredYears int) time.Time {
+	if timeUnit < time.Millisecond {
+		return time.Time{}
+	}
+
+	now := time.Now().UTC()
+	until := now.AddDate(desiredYears, 0, 0)
+
+	candidates := []time.Time{
+		time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+	}
+	for _, start := range candidates {
+		if coversUntil(start, until, timeUnit) {
+			return start
+		}
+	}
+	return time.Time{}
+}
+
 // WithTTL sets the machine ID lease duration
 // Should be long enough to survive temporary network issues
 func WithTTL(d time.Duration) Option {
@@ -117,10 +172,52 @@ func WithRenewFrequency(d time.Duration) Option {
 	}
 }
 
+// WithRenewJitter randomizes each renewal delay within [renewFreq-d, renewFreq+d]
+// Spreads lease renewals of replicas started together; renewFreq+d must stay below TTL
+func WithRenewJitter(d time.Duration) Option {
+	return func(c *generatorConfig) error {
+		if d < 0 {
+			return errors.New("renew jitter cannot be negative")
+		}
+		c.renewJitter = d
+		return nil
+	}
+}
+
+// WithLifespanGuard requires the ID space to last at least minYears from now
+// Guards against fine time units silently shortening the usable lifespan
+func WithLifespanGuard(minYears int) Option {
+	return func(c *generatorConfig) error {
+		if minYears < 0 {
+			return errors.New("lifespan guard cannot be negative")
+		}
+		c.minLifespanYears = minYears
+		return nil
+	}
+}
+
+// WithInstanceMetadata attaches metadata to the machine ID lease
+// Only passed on when the repo implements MetadataRepo; ignored otherwise
+func WithInstanceMetadata(meta map[string]string) Option {
+	return func(c *generatorConfig) error {
+		c.meta = make(map[string]string, len(meta))
+		for k, v := range meta {
+			c.meta[k] = v
+		}
+		return nil
+	}
+}
+
 // New creates a new Generator with distributed machine ID management
 // repo: required - manages machine ID allocation and uniqueness
 // opts: optional - configuration overrides
 func New(repo Repo, opts ...Option) (*Generator, error) {
+	return NewContext(context.Background(), repo, opts...)
+}
+
+// NewContext is like New but gives up when ctx is done, both while waiting out a start time
+// tolerated by WithStartTimeSkew and while acquiring the machine ID
+func NewContext(ctx context.Context, repo Repo, opts ...Option) (*Generator, error) {
 	if repo == nil {
 		return nil, errors.New("sonyflakex repo is required")
 	}
@@ -136,11 +233,16 @@ func New(repo Repo, opts ...Option) (*Generator, error) {
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// sonyflake cannot issue IDs before its epoch, so sit out a start time tolerated by the skew
+	if err := waitUntil(ctx, cfg.settings.StartTime); err != nil {
+		return nil, fmt.Errorf("wait for start time: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Acquire unique machine ID from repo
-	machineID, err := repo.AcquireMachineID(ctx, cfg.ttl)
+	machineID, err := acquireMachineID(ctx, repo, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrAcquireMachineID, err)
 	}
@@ -169,6 +271,8 @@ func New(repo Repo, opts ...Option) (*Generator, error) {
 		doneChan:  make(chan struct{}),
 		ttl:       cfg.ttl,
 		renewFreq: cfg.renewFreq,
+		jitter:    cfg.renewJitter,
+		timeUnit:  cfg.settings.TimeUnit,
 	}
 
 	// Start background heartbeat to keep machine ID alive
@@ -178,7 +282,11 @@ func New(repo Repo, opts ...Option) (*Generator, error) {
 }
 
 // NextID generates the next unique ID
+// Returns ErrGeneratorStopped once Stop has been called, as the machine ID may be leased elsewhere
 func (g *Generator) NextID() (int64, error) {
+	if g.stopped.Load() {
+		return 0, ErrGeneratorStopped
+	}
 	return g.sf.NextID()
 }
 
@@ -192,10 +300,29 @@ func (g *Generator) Decompose(id int64) map[string]int64 {
 	return g.sf.Decompose(id)
 }
 
+// Explain returns a human-readable multi-line breakdown of an ID for debugging tools
+// Lists the generation time, machine ID and sequence, followed by the raw bit fields
+func (g *Generator) Explain(id int64) string {
+	parts := g.sf.Decompose(id)
+	var b strings.Builder
+	fmt.Fprintf(&b, "id:       %d\n", id)
+	fmt.Fprintf(&b, "time:     %s\n", g.ToTime(id).UTC().Format(time.RFC3339Nano))
+	fmt.Fprintf(&b, "elapsed:  %d x %v\n", parts["time"], g.timeUnit)
+	fmt.Fprintf(&b, "machine:  %d\n", parts["machine"])
+	fmt.Fprintf(&b, "sequence: %d\n", parts["sequence"])
+	fmt.Fprintf(&b, "bits:     time=%0*b sequence=%0*b machine=%0*b",
+		defaultBitsTime, parts["time"],
+		defaultBitsSequence, parts["sequence"],
+		defaultBitsMachine, parts["machine"])
+	return b.String()
+}
+
 // Stop gracefully stops the generator and releases the machine ID
 // Should be called before application shutdown
 func (g *Generator) Stop(ctx context.Context) error {
 	g.stopOnce.Do(func() {
+		// Refuse new IDs before the machine ID can be handed to another instance
+		g.stopped.Store(true)
 		// Signal heartbeat to stop
 		close(g.stopChan)
 		// Wait for heartbeat to exit
@@ -210,26 +337,66 @@ func (g *Generator) Stop(ctx context.Context) error {
 
 // heartbeat periodically renews the machine ID lease to maintain uniqueness
 func (g *Generator) heartbeat() {
-	ticker := time.NewTicker(g.renewFreq)
-	defer ticker.Stop()
+	timer := time.NewTimer(renewDelay(g.renewFreq, g.jitter))
+	defer timer.Stop()
 
 	defer close(g.doneChan)
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-timer.C:
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			_ = g.repo.RenewMachineID(ctx, g.machineID, g.ttl)
 			cancel()
+			timer.Reset(renewDelay(g.renewFreq, g.jitter))
 		case <-g.stopChan:
 			return
 		}
 	}
 }
 
+// renewDelay returns the next renewal delay, drawn uniformly from [renewFreq-jitter, renewFreq+jitter]
+// with a 1ms floor; validateConfig guarantees renewFreq+jitter leaves a margin below the TTL
+func renewDelay(renewFreq, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return renewFreq
+	}
+	lo := max(renewFreq-jitter, time.Millisecond)
+	hi := renewFreq + jitter
+	if hi <= lo {
+		return renewFreq
+	}
+	return lo + time.Duration(rand.Int64N(int64(hi-lo)+1))
+}
+
+// waitUntil blocks until t has passed or ctx is done
+func waitUntil(ctx context.Context, t time.Time) error {
+	d := time.Until(t)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// acquireMachineID acquires a machine ID, passing instance metadata when the repo supports it
+func acquireMachineID(ctx context.Context, repo Repo, cfg *generatorConfig) (int, error) {
+	if metaRepo, ok := repo.(MetadataRepo); ok && len(cfg.meta) > 0 {
+		return metaRepo.AcquireMachineIDWithMeta(ctx, cfg.ttl, cfg.meta)
+	}
+	return repo.AcquireMachineID(ctx, cfg.ttl)
+}
+
 // validateConfig ensures configuration meets production requirements
 func validateConfig(cfg *generatorConfig) error {
-	if cfg.settings.StartTime.After(time.Now()) {
+	now := cfg.now()
+	if cfg.settings.StartTime.After(now.Add(cfg.startTimeSkew)) {
 		return ErrInvalidStartTime
 	}
 	if cfg.settings.TimeUnit < time.Millisecond {
@@ -241,5 +408,33 @@ func validateConfig(cfg *generatorConfig) error {
 	if cfg.renewFreq >= cfg.ttl {
 		return errors.New("renew frequency must be less than TTL")
 	}
+	if cfg.renewFreq+cfg.renewJitter >= cfg.ttl {
+		return errors.New("renew frequency plus jitter must be less than TTL")
+	}
+	required := now.AddDate(cfg.minLifespanYears, 0, 0)
+	if !coversUntil(cfg.settings.StartTime, required, cfg.settings.TimeUnit) {
+		return fmt.Errorf("%w: %v time unit from %s overflows at %s, need at least %d years from now",
+			ErrLifespanTooShort,
+			cfg.settings.TimeUnit,
+			cfg.settings.StartTime.UTC().Format(time.DateOnly),
+			lifespanEnd(cfg.settings.StartTime, cfg.settings.TimeUnit).Format(time.DateOnly),
+			cfg.minLifespanYears,
+		)
+	}
 	return nil
 }
+
+// maxElapsedSeconds returns the span in seconds the default time bits can represent at the given unit
+func maxElapsedSeconds(timeUnit time.Duration) float64 {
+	return float64(int64(1)<<defaultBitsTime) * timeUnit.Seconds()
+}
+
+// coversUntil reports whether IDs generated at until still fit in the time bits for the given epoch
+func coversUntil(start, until time.Time, timeUnit time.Duration) bool {
+	return float64(until.Unix()-start.Unix()) < maxElapsedSeconds(timeUnit)
+}
+
+// lifespanEnd returns the time at which the default time bits overflow for the given epoch
+func lifespanEnd(start time.Time, timeUnit time.Duration) time.Time {
+	return time.Unix(start.Unix()+int64(maxElapsedSeconds(timeUnit)), 0).UTC()
+}
@@ -3,6 +3,8 @@ package sonyflakex
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -75,6 +77,19 @@ func (m *MockRepo) GetCallCounts() (acquire, renew, release int) {
 	return m.acquireCallCount, m.renewCallCount, m.releaseCallCount
 }
 
+// MockMetadataRepo implements MetadataRepo on top of MockRepo, capturing metadata
+type MockMetadataRepo struct {
+	*MockRepo
+	meta map[string]string
+}
+
+func (m *MockMetadataRepo) AcquireMachineIDWithMeta(ctx context.Context, ttl time.Duration, meta map[string]string) (int, error) {
+	m.mu.Lock()
+	m.meta = meta
+	m.mu.Unlock()
+	return m.AcquireMachineID(ctx, ttl)
+}
+
 // TestNew tests generator creation with valid configuration
 func TestNew(t *testing.T) {
 	repo := NewMockRepo()
@@ -173,6 +188,56 @@ func TestWithStartTime_Future(t *testing.T) {
 	}
 }
 
+// TestWithStartTimeSkew tests a slightly future start time is tolerated only within the skew
+func TestWithStartTimeSkew(t *testing.T) {
+	startTime := time.Now().Add(2 * time.Second)
+
+	_, err := New(NewMockRepo(), WithStartTime(startTime))
+	if !errors.Is(err, ErrInvalidStartTime) {
+		t.Fatalf("without skew: error = %v, want ErrInvalidStartTime", err)
+	}
+
+	g, err := New(NewMockRepo(), WithStartTime(startTime), WithStartTimeSkew(5*time.Second))
+	if err != nil {
+		t.Fatalf("New() with skew failed: %v", err)
+	}
+	defer g.Stop(context.Background())
+
+	id, err := g.NextID()
+	if err != nil {
+		t.Fatalf("NextID() failed: %v", err)
+	}
+	if genTime := g.ToTime(id); genTime.Before(startTime.Truncate(10 * time.Millisecond)) {
+		t.Errorf("ToTime() = %v, want >= %v", genTime, startTime)
+	}
+}
+
+// TestNewContext_CancelWait tests a cancelled context ends the wait for a future start time
+func TestNewContext_CancelWait(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	repo := NewMockRepo()
+	start := time.Now()
+	_, err := NewContext(ctx, repo, WithStartTime(time.Now().Add(time.Minute)), WithStartTimeSkew(2*time.Minute))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("NewContext() returned after %v, want prompt return", elapsed)
+	}
+	if repo.acquireCallCount != 0 {
+		t.Errorf("AcquireMachineID called %d times, want 0", repo.acquireCallCount)
+	}
+}
+
+// TestWithStartTimeSkew_Negative tests validation of a negative skew
+func TestWithStartTimeSkew_Negative(t *testing.T) {
+	if _, err := New(NewMockRepo(), WithStartTimeSkew(-time.Second)); err == nil {
+		t.Error("New() with negative skew succeeded, want error")
+	}
+}
+
 // TestWithTimeUnit tests time unit option
 func TestWithTimeUnit(t *testing.T) {
 	repo := NewMockRepo()
@@ -204,6 +269,159 @@ func TestWithTimeUnit_Invalid(t *testing.T) {
 	}
 }
 
+// TestStartTimeForLifespan tests the derived epoch leaves the requested lifespan
+func TestStartTimeForLifespan(t *testing.T) {
+	tests := []struct {
+		unit  time.Duration
+		years int
+	}{
+		{time.Millisecond, 1},
+		{time.Millisecond, 10},
+		{time.Millisecond, 17},
+		{10 * time.Millisecond, 50},
+		{10 * time.Millisecond, 100},
+		{10 * time.Millisecond, 170},
+		{100 * time.Millisecond, 1000},
+	}
+
+	for _, tt := range tests {
+		start := StartTimeForLifespan(tt.unit, tt.years)
+		if start.IsZero() {
+			t.Fatalf("StartTimeForLifespan(%v, %d) returned zero time", tt.unit, tt.years)
+		}
+		now := time.Now()
+		if start.After(now) {
+			t.Errorf("StartTimeForLifespan(%v, %d) = %v, want in the past", tt.unit, tt.years, start)
+		}
+		if !coversUntil(start, now.AddDate(tt.years, 0, 0), tt.unit) {
+			t.Errorf("StartTimeForLifespan(%v, %d) = %v overflows before %d years", tt.unit, tt.years, start, tt.years)
+		}
+
+		repo := NewMockRepo()
+		g, err := New(repo, WithStartTime(start), WithTimeUnit(tt.unit))
+		if err != nil {
+			t.Fatalf("New() with derived start time failed: %v", err)
+		}
+		if _, err := g.NextID(); err != nil {
+			t.Errorf("NextID() with derived start time failed: %v", err)
+		}
+		_ = g.Stop(context.Background())
+	}
+}
+
+// TestStartTimeForLifespan_Unreachable tests a lifespan beyond the time bits yields the zero time
+func TestStartTimeForLifespan_Unreachable(t *testing.T) {
+	if start := StartTimeForLifespan(time.Millisecond, 20); !start.IsZero() {
+		t.Errorf("StartTimeForLifespan(1ms, 20) = %v, want zero time", start)
+	}
+	if start := StartTimeForLifespan(500*time.Microsecond, 1); !start.IsZero() {
+		t.Errorf("StartTimeForLifespan(500us, 1) = %v, want zero time", start)
+	}
+}
+
+// TestWithLifespanGuard tests the guard rejects configurations with too short a lifespan
+func TestWithLifespanGuard(t *testing.T) {
+	// 1ms unit from the default 2025-01-01 epoch overflows in 2042
+	repo := NewMockRepo()
+	_, err := New(repo, WithTimeUnit(time.Millisecond), WithLifespanGuard(50))
+	if !errors.Is(err, ErrLifespanTooShort) {
+		t.Fatalf("error = %v, want ErrLifespanTooShort", err)
+	}
+	acquire, _, _ := repo.GetCallCounts()
+	if acquire != 0 {
+		t.Errorf("AcquireMachineID called %d times, want 0", acquire)
+	}
+
+	// Pin the clock so the outcome does not drift as the overflow date approaches
+	fixedNow := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		timeUnit time.Duration
+		minYears int
+		wantErr  bool
+	}{
+		{"1ms unit covers 5 years", time.Millisecond, 5, false},
+		{"1ms unit falls short of 50 years", time.Millisecond, 50, true},
+		{"10ms unit covers 50 years", 10 * time.Millisecond, 50, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaultGeneratorConfig()
+			cfg.now = func() time.Time { return fixedNow }
+			for _, opt := range []Option{WithTimeUnit(tt.timeUnit), WithLifespanGuard(tt.minYears)} {
+				if err := opt(cfg); err != nil {
+					t.Fatalf("apply option failed: %v", err)
+				}
+			}
+			err := validateConfig(cfg)
+			if got := errors.Is(err, ErrLifespanTooShort); got != tt.wantErr {
+				t.Errorf("validateConfig() error = %v, want ErrLifespanTooShort %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestWithLifespanGuard_Negative tests validation of negative guard
+func TestWithLifespanGuard_Negative(t *testing.T) {
+	_, err := New(NewMockRepo(), WithLifespanGuard(-1))
+	if err == nil {
+		t.Fatal("New() should fail with negative lifespan guard")
+	}
+}
+
+// TestValidateConfig_Overflowed tests an epoch whose lifespan already ended is rejected
+func TestValidateConfig_Overflowed(t *testing.T) {
+	cfg := defaultGeneratorConfig()
+	cfg.settings.StartTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg.settings.TimeUnit = time.Millisecond
+
+	if err := validateConfig(cfg); !errors.Is(err, ErrLifespanTooShort) {
+		t.Errorf("validateConfig() = %v, want ErrLifespanTooShort", err)
+	}
+}
+
+// TestWithInstanceMetadata tests metadata is passed to repos supporting it
+func TestWithInstanceMetadata(t *testing.T) {
+	repo := &MockMetadataRepo{MockRepo: NewMockRepo()}
+	meta := map[string]string{"pod": "app-0", "namespace": "prod"}
+
+	g, err := New(repo, WithInstanceMetadata(meta))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	defer g.Stop(context.Background())
+
+	// Mutating the caller's map must not affect the lease metadata
+	meta["pod"] = "changed"
+
+	repo.mu.Lock()
+	got := repo.meta
+	repo.mu.Unlock()
+	if got["pod"] != "app-0" || got["namespace"] != "prod" || len(got) != 2 {
+		t.Errorf("metadata = %v, want pod=app-0 namespace=prod", got)
+	}
+	acquire, _, _ := repo.GetCallCounts()
+	if acquire != 1 {
+		t.Errorf("AcquireMachineID called %d times, want 1", acquire)
+	}
+}
+
+// TestWithInstanceMetadata_PlainRepo tests metadata is ignored for repos without support
+func TestWithInstanceMetadata_PlainRepo(t *testing.T) {
+	repo := NewMockRepo()
+
+	g, err := New(repo, WithInstanceMetadata(map[string]string{"pod": "app-0"}))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	defer g.Stop(context.Background())
+
+	acquire, _, _ := repo.GetCallCounts()
+	if acquire != 1 {
+		t.Errorf("AcquireMachineID called %d times, want 1", acquire)
+	}
+}
+
 // TestWithTTL tests TTL option
 func TestWithTTL(t *testing.T) {
 	repo := NewMockRepo()
@@ -361,6 +579,34 @@ func TestDecompose(t *testing.T) {
 	}
 }
 
+// TestExplain tests the formatted breakdown of a known ID
+func TestExplain(t *testing.T) {
+	g, err := New(NewMockRepo(), WithStartTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	defer g.Stop(context.Background())
+
+	// 150 units of 10ms after the epoch, sequence 3, machine 7
+	id := int64(150)<<(defaultBitsSequence+defaultBitsMachine) | 3<<defaultBitsMachine | 7
+
+	got := g.Explain(id)
+	for _, want := range []string{
+		fmt.Sprintf("id:       %d", id),
+		"time:     2024-01-01T00:00:01.5Z",
+		"elapsed:  150 x 10ms",
+		"machine:  7",
+		"sequence: 3",
+		"sequence=00000011",
+		"machine=0000000000000111",
+		"time=000000000000000000000000000000010010110",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Explain() = %q, missing %q", got, want)
+		}
+	}
+}
+
 // TestStop tests graceful shutdown
 func TestStop(t *testing.T) {
 	repo := NewMockRepo()
@@ -381,6 +627,30 @@ func TestStop(t *testing.T) {
 	}
 }
 
+// TestNextID_AfterStop tests NextID refuses to generate IDs once stopped
+func TestNextID_AfterStop(t *testing.T) {
+	repo := NewMockRepo()
+	g, err := New(repo, WithStartTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if _, err := g.NextID(); err != nil {
+		t.Fatalf("NextID() before Stop failed: %v", err)
+	}
+	if err := g.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() failed: %v", err)
+	}
+
+	id, err := g.NextID()
+	if !errors.Is(err, ErrGeneratorStopped) {
+		t.Errorf("NextID() after Stop error = %v, want ErrGeneratorStopped", err)
+	}
+	if id != 0 {
+		t.Errorf("NextID() after Stop id = %d, want 0", id)
+	}
+}
+
 // TestStop_ReleaseFailure tests error handling when release fails
 func TestStop_ReleaseFailure(t *testing.T) {
 	repo := NewMockRepo()
@@ -423,6 +693,125 @@ func TestHeartbeat(t *testing.T) {
 	}
 }
 
+// TestRenewDelay_Jitter tests jittered delays stay in range
+func TestRenewDelay_Jitter(t *testing.T) {
+	tests := []struct {
+		name      string
+		renewFreq time.Duration
+		jitter    time.Duration
+		wantMin   time.Duration
+		wantMax   time.Duration
+	}{
+		{"within range", 10 * time.Second, 2 * time.Second, 8 * time.Second, 12 * time.Second},
+		{"floored at 1ms", 10 * time.Second, 10 * time.Second, time.Millisecond, 20 * time.Second},
+		{"no jitter", 10 * time.Second, 0, 10 * time.Second, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := make(map[time.Duration]bool)
+			for i := 0; i < 1000; i++ {
+				d := renewDelay(tt.renewFreq, tt.jitter)
+				if d < tt.wantMin || d > tt.wantMax {
+					t.Fatalf("renewDelay() = %v, want within [%v, %v]", d, tt.wantMin, tt.wantMax)
+				}
+				seen[d] = true
+			}
+			if tt.jitter > 0 && len(seen) < 2 {
+				t.Errorf("renewDelay() returned %d distinct delays, want spread", len(seen))
+			}
+		})
+	}
+}
+
+// TestWithRenewJitter_TTLMargin tests renewals keep a margin below TTL and jitter reaching TTL is rejected
+func TestWithRenewJitter_TTLMargin(t *testing.T) {
+	tests := []struct {
+		name      string
+		renewFreq time.Duration
+		jitter    time.Duration
+		wantErr   bool
+	}{
+		{"margin kept", 10 * time.Second, time.Second, false},
+		{"jitter reaches TTL", 10 * time.Second, 2 * time.Second, true},
+		{"jitter exceeds TTL", 10 * time.Second, 10 * time.Second, true},
+	}
+
+	const ttl = 12 * time.Second
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaultGeneratorConfig()
+			cfg.ttl = ttl
+			cfg.renewFreq = tt.renewFreq
+			cfg.renewJitter = tt.jitter
+			err := validateConfig(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			margin := ttl - (tt.renewFreq + tt.jitter)
+			for i := 0; i < 1000; i++ {
+				if d := renewDelay(tt.renewFreq, tt.jitter); ttl-d < margin {
+					t.Fatalf("renewDelay() = %v, want at least %v below TTL %v", d, margin, ttl)
+				}
+			}
+		})
+	}
+}
+
+// TestWithRenewJitter_SpreadsRenewals tests generators started together do not renew in lockstep
+func TestWithRenewJitter_SpreadsRenewals(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		renews []time.Time
+	)
+	start := time.Now()
+
+	for i := 0; i < 5; i++ {
+		repo := NewMockRepo()
+		repo.renewFunc = func(ctx context.Context, machineID int, ttl time.Duration) error {
+			mu.Lock()
+			defer mu.Unlock()
+			renews = append(renews, time.Now())
+			return nil
+		}
+		g, err := New(repo,
+			WithStartTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			WithTTL(time.Second),
+			WithRenewFrequency(100*time.Millisecond),
+			WithRenewJitter(80*time.Millisecond))
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		defer g.Stop(context.Background())
+	}
+
+	time.Sleep(250 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(renews) < 5 {
+		t.Fatalf("got %d renewals, want >= 5", len(renews))
+	}
+	buckets := make(map[time.Duration]bool)
+	for _, at := range renews {
+		buckets[at.Sub(start).Truncate(10*time.Millisecond)] = true
+	}
+	if len(buckets) < 2 {
+		t.Errorf("all %d renewals fell in the same 10ms tick, want spread", len(renews))
+	}
+}
+
+// TestWithRenewJitter_Negative tests negative jitter is rejected
+func TestWithRenewJitter_Negative(t *testing.T) {
+	_, err := New(NewMockRepo(), WithRenewJitter(-time.Second))
+	if err == nil {
+		t.Fatal("New() should fail with negative jitter")
+	}
+}
+
 // TestHeartbeat_StopsAfterStop tests heartbeat stops after Stop()
 func TestHeartbeat_StopsAfterStop(t *testing.T) {
 	repo := NewMockRepo()
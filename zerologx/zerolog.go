@@ -4,6 +4,7 @@ package zerologx
 import (
 	"io"
 	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -22,6 +23,7 @@ type Config struct {
 	hooks          []zerolog.Hook
 	pretty         bool
 	consoleTimeFmt string
+	dynamicLevel   *atomic.Int32 // set by NewDynamic; read on every event
 }
 
 // WithLevel sets the log level
@@ -86,6 +88,30 @@ func WithConsoleTimeFormat(format string) Option {
 // Required parameter: output (output destination)
 // Optional parameters: passed via options pattern
 func New(output io.Writer, opts ...Option) zerolog.Logger {
+	return newConfig(output, opts...).build()
+}
+
+// NewDynamic initializes a zerolog Logger whose level can be changed at runtime
+// through the returned Config, without touching the global zerolog level
+// Events below the current level are dropped before they are built, but the logger itself runs at
+// TraceLevel: GetLevel reports TraceLevel, and calling Sample on it replaces the level filter
+func NewDynamic(output io.Writer, opts ...Option) (zerolog.Logger, *Config) {
+	config := newConfig(output, opts...)
+	config.dynamicLevel = new(atomic.Int32)
+	config.dynamicLevel.Store(int32(config.level))
+	return config.build(), config
+}
+
+// UpdateLevel changes the level of the logger created by NewDynamic with this Config
+// Other loggers are unaffected; it is a no-op for configs not created by NewDynamic
+func (c *Config) UpdateLevel(level zerolog.Level) {
+	if c.dynamicLevel != nil {
+		c.dynamicLevel.Store(int32(level))
+	}
+}
+
+// newConfig applies options on top of the default configuration
+func newConfig(output io.Writer, opts ...Option) *Config {
 	// Set default configuration
 	config := &Config{
 		level:      zerolog.InfoLevel,
@@ -100,40 +126,57 @@ func New(output io.Writer, opts ...Option) zerolog.Logger {
 		opt(config)
 	}
 
+	return config
+}
+
+// build creates the zerolog Logger described by the configuration
+func (c *Config) build() zerolog.Logger {
+	level := c.level
+	if c.dynamicLevel != nil {
+		// Let every level past the logger; levelSampler rejects events below the current level
+		level = zerolog.TraceLevel
+	}
+
 	var logger zerolog.Logger
 
 	// If pretty output is needed, use ConsoleWriter
-	if config.pretty {
+	if c.pretty {
 		consoleWriter := zerolog.ConsoleWriter{
-			Out:     config.output,
+			Out:     c.output,
 			NoColor: false,
 			TimeFormat: func() string {
-				if config.consoleTimeFmt != "" {
-					return config.consoleTimeFmt
+				if c.consoleTimeFmt != "" {
+					return c.consoleTimeFmt
 				}
-				return config.timeFormat
+				return c.timeFormat
 			}(),
 		}
-		logger = zerolog.New(consoleWriter).Level(config.level)
+		logger = zerolog.New(consoleWriter).Level(level)
 	} else {
-		logger = zerolog.New(config.output).Level(config.level)
+		logger = zerolog.New(c.output).Level(level)
 	}
 
 	// Add timestamp
 	logger = logger.With().Timestamp().Logger()
 
 	// Enable caller information
-	if config.caller {
+	if c.caller {
 		logger = logger.With().Caller().Logger()
 	}
 
-	// Set sampling
-	if config.sampling != nil {
-		logger = logger.Sample(config.sampling)
+	// Set sampling; a dynamic level gates events before they are built, ahead of any sampler
+	if c.dynamicLevel != nil {
+		sampler := levelSampler{level: c.dynamicLevel}
+		if c.sampling != nil {
+			sampler.next = c.sampling
+		}
+		logger = logger.Sample(sampler)
+	} else if c.sampling != nil {
+		logger = logger.Sample(c.sampling)
 	}
 
 	// Add hooks
-	for _, hook := range config.hooks {
+	for _, hook := range c.hooks {
 		logger = logger.Hook(hook)
 	}
 
@@ -185,6 +228,22 @@ func NewFileLogger(filepath string, opts ...Option) (zerolog.Logger, error) {
 	return logger, nil
 }
 
+// UpdateLogLevel sets the global zerolog level, affecting every logger in the process
+// Use NewDynamic and Config.UpdateLevel to change a single logger's level
 func UpdateLogLevel(level zerolog.Level) {
 	zerolog.SetGlobalLevel(level)
 }
+
+// levelSampler rejects events below the current dynamic level before zerolog builds them, so
+// their fields are never encoded and hooks never see them; accepted events go on to next
+type levelSampler struct {
+	level *atomic.Int32
+	next  zerolog.Sampler
+}
+
+func (s levelSampler) Sample(level zerolog.Level) bool {
+	if level < zerolog.Level(s.level.Load()) {
+		return false
+	}
+	return s.next == nil || s.next.Sample(level)
+}
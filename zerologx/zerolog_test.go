@@ -460,3 +460,77 @@ func TestTimestampPresence(t *testing.T) {
 		t.Error("Expected 'time' field in log output")
 	}
 }
+
+// TestNewDynamicUpdateLevel verifies UpdateLevel changes only the owning logger
+func TestNewDynamicUpdateLevel(t *testing.T) {
+	globalLevel := zerolog.GlobalLevel()
+	accessBuf := &bytes.Buffer{}
+	appBuf := &bytes.Buffer{}
+	accessLogger, accessCfg := NewDynamic(accessBuf, WithLevel(zerolog.InfoLevel))
+	appLogger, _ := NewDynamic(appBuf, WithLevel(zerolog.InfoLevel))
+
+	accessLogger.Debug().Msg("debug before update")
+	if accessBuf.Len() != 0 {
+		t.Fatalf("Expected debug to be filtered at info level, got %q", accessBuf.String())
+	}
+
+	accessCfg.UpdateLevel(zerolog.ErrorLevel)
+
+	accessLogger.Info().Msg("info after update")
+	if accessBuf.Len() != 0 {
+		t.Errorf("Expected info to be filtered after raising level, got %q", accessBuf.String())
+	}
+	accessLogger.Error().Msg("error after update")
+	if !strings.Contains(accessBuf.String(), "error after update") {
+		t.Errorf("Expected error to be logged after raising level, got %q", accessBuf.String())
+	}
+
+	appLogger.Info().Msg("app info")
+	if !strings.Contains(appBuf.String(), "app info") {
+		t.Errorf("Expected other logger to keep its level, got %q", appBuf.String())
+	}
+	if zerolog.GlobalLevel() != globalLevel {
+		t.Errorf("Expected global level untouched, got %v", zerolog.GlobalLevel())
+	}
+
+	accessBuf.Reset()
+	accessCfg.UpdateLevel(zerolog.DebugLevel)
+	accessLogger.Debug().Msg("debug after lowering")
+	if !strings.Contains(accessBuf.String(), "debug after lowering") {
+		t.Errorf("Expected debug to be logged after lowering level, got %q", accessBuf.String())
+	}
+}
+
+// TestNewDynamicFiltersBeforeHooks verifies events below the dynamic level never reach hooks or the sampler
+func TestNewDynamicFiltersBeforeHooks(t *testing.T) {
+	buf := &bytes.Buffer{}
+	var hooked []zerolog.Level
+	hook := zerolog.HookFunc(func(e *zerolog.Event, level zerolog.Level, msg string) {
+		hooked = append(hooked, level)
+	})
+	logger, cfg := NewDynamic(buf, WithLevel(zerolog.WarnLevel), WithHook(hook), WithSampling(2))
+
+	for i := 0; i < 4; i++ {
+		logger.Info().Msg("filtered")
+		logger.Warn().Msg("kept")
+	}
+	if len(hooked) != 2 {
+		t.Fatalf("Expected hooks to see 2 sampled warn events, got %v", hooked)
+	}
+	for _, level := range hooked {
+		if level != zerolog.WarnLevel {
+			t.Errorf("Expected only warn events to reach hooks, got %v", level)
+		}
+	}
+	if strings.Contains(buf.String(), "filtered") {
+		t.Errorf("Expected info events to be filtered, got %q", buf.String())
+	}
+
+	cfg.UpdateLevel(zerolog.InfoLevel)
+	hooked = nil
+	logger.Info().Msg("info after lowering")
+	logger.Info().Msg("info after lowering")
+	if len(hooked) != 1 {
+		t.Errorf("Expected sampling to apply after lowering the level, got %v", hooked)
+	}
+}